@@ -0,0 +1,12 @@
+// sqlfmt-vet reports SQL statements which are not formatted by sqlfmt
+// it can be run as go vet -vettool=$(which sqlfmt-vet) ./...
+package main
+
+import (
+	"github.com/kanmu/go-sqlfmt/sqlfmt/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
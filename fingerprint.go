@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+// fingerprintMain runs "sqlfmt fingerprint", which lists SQL statements in .go files with their fingerprints
+// each line is the position, the fingerprint and the normalized SQL statement separated by tabs
+func fingerprintMain(args []string) {
+	fs := flag.NewFlagSet("sqlfmt fingerprint", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: sqlfmt fingerprint [path ...]\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !info.IsDir() {
+			if err := fingerprintFile(path, os.Stdout); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+		filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+			if err == nil && isGoFile(info) {
+				err = fingerprintFile(path, os.Stdout)
+			}
+			if err != nil {
+				log.Println(err)
+			}
+			return nil
+		})
+	}
+}
+
+// fingerprintFile writes SQL statements in .go file with their fingerprints to out
+// SQL statements which can not be normalized are logged and skipped
+func fingerprintFile(filename string, out io.Writer) error {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return errors.Wrap(err, "ioutil.ReadFile failed")
+	}
+	queries, err := sqlfmt.Queries(filename, src)
+	if err != nil {
+		return errors.Wrap(err, "sqlfmt.Queries failed")
+	}
+
+	for _, q := range queries {
+		pos := newPosition(src, q.Start)
+		// printf verbs of fmt.Sprintf templates are not scanned as operators
+		opt := &sqlfmt.Options{PrintfVerbs: q.PrintfVerbs}
+		normalized, err := sqlfmt.NormalizeWithOptions(q.SQL, opt)
+		if err != nil {
+			log.Printf("%s:%d:%d: %v", filename, pos.Line, pos.Column, err)
+			continue
+		}
+		fingerprint, err := sqlfmt.FingerprintWithOptions(q.SQL, opt)
+		if err != nil {
+			log.Printf("%s:%d:%d: %v", filename, pos.Line, pos.Column, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s:%d:%d\t%s\t%s\n", filename, pos.Line, pos.Column, fingerprint, normalized)
+	}
+	return nil
+}
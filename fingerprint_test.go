@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlfmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "main.go")
+	src := "package main\n\nfunc main() {\n\tdb.Query(fmt.Sprintf(`select xxx from %s -- xxx\nwhere xxx in (1, 2)`, table))\n}\n"
+	if err := ioutil.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := fingerprintFile(filename, &out); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	fields := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\t")
+	if len(fields) != 3 {
+		t.Fatalf("want position, fingerprint and SQL statement, got %#v", out.String())
+	}
+	if want := filename + ":4:23"; fields[0] != want {
+		t.Errorf("want %#v, got %#v", want, fields[0])
+	}
+	if want := "SELECT xxx FROM %s WHERE xxx IN (?)"; fields[2] != want {
+		t.Errorf("want %#v, got %#v", want, fields[2])
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+// hunkHeader matches the header of hunk such as "@@ -10,2 +12,3 @@", capturing the start and the count of new lines
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// changedRanges returns byte ranges of src in filename which have changed from git revision base
+// untracked file is regarded as changed entirely
+func changedRanges(base, filename string, src []byte) ([]sqlfmt.Range, error) {
+	dir, name := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+
+	tracked, err := git(dir, "ls-files", "--", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "git ls-files failed")
+	}
+	if len(tracked) == 0 {
+		return []sqlfmt.Range{{Start: 0, End: len(src)}}, nil
+	}
+
+	out, err := git(dir, "diff", "--no-color", "--no-ext-diff", "-U0", base, "--", name)
+	if err != nil {
+		return nil, errors.Wrap(err, "git diff failed")
+	}
+	return parseHunks(out, src), nil
+}
+
+// parseHunks returns byte ranges of src which are new lines of hunks in out of git diff -U0
+func parseHunks(out []byte, src []byte) []sqlfmt.Range {
+	var ranges []sqlfmt.Range
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		m := hunkHeader.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		from, _ := strconv.Atoi(string(m[1]))
+		count := 1
+		if len(m[2]) > 0 {
+			count, _ = strconv.Atoi(string(m[2]))
+		}
+
+		// hunk only with deleted lines is regarded as changing the line after which the lines are deleted
+		to := from + count - 1
+		if count == 0 {
+			to = from
+		}
+		if from < 1 {
+			from = 1
+		}
+		if to < from {
+			to = from
+		}
+		start, end := lineOffsets(src, from, to)
+		ranges = append(ranges, sqlfmt.Range{Start: start, End: end})
+	}
+	return ranges
+}
+
+// git runs git command in dir and returns its standard output
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("%v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+func TestParseHunks(t *testing.T) {
+	src := []byte("line1\nline2\nline3\n")
+	tests := []struct {
+		name string
+		out  string
+		want []sqlfmt.Range
+	}{
+		{
+			name: "changed line without count",
+			out:  "@@ -2 +2 @@\n-xxx\n+line2\n",
+			want: []sqlfmt.Range{{Start: 6, End: 11}},
+		},
+		{
+			name: "added lines",
+			out:  "@@ -1,0 +2,2 @@\n+line2\n+line3\n",
+			want: []sqlfmt.Range{{Start: 6, End: 17}},
+		},
+		{
+			name: "deleted lines after line",
+			out:  "@@ -3,2 +2,0 @@\n-xxx\n-xxx\n",
+			want: []sqlfmt.Range{{Start: 6, End: 11}},
+		},
+		{
+			name: "deleted lines at the beginning",
+			out:  "@@ -1,2 +0,0 @@\n-xxx\n-xxx\n",
+			want: []sqlfmt.Range{{Start: 0, End: 5}},
+		},
+		{
+			name: "multiple hunks with headers",
+			out:  "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-xxx\n+@@ -3 +3 @@\n@@ -3,0 +3 @@ func main() {\n+line3\n",
+			want: []sqlfmt.Range{{Start: 0, End: 5}, {Start: 12, End: 17}},
+		},
+		{
+			name: "line after the end of src",
+			out:  "@@ -3 +5 @@\n",
+			want: []sqlfmt.Range{{Start: 18, End: 18}},
+		},
+		{
+			name: "no hunks",
+			out:  "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseHunks([]byte(tt.out), src)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,5 +1,14 @@
 module github.com/kanmu/go-sqlfmt
 
-go 1.13
+go 1.18
 
-require github.com/pkg/errors v0.8.1
+require (
+	github.com/pkg/errors v0.8.1
+	golang.org/x/tools v0.1.0
+)
+
+require (
+	golang.org/x/mod v0.3.0 // indirect
+	golang.org/x/sys v0.0.0-20210119212857-b64e53b001e4 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+)
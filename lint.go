@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lint"
+)
+
+// lintMain runs "sqlfmt lint", which checks SQL statements in .go and .sql files with lint rules
+// it exits with status 1 if any problem is found
+func lintMain(args []string) {
+	fs := flag.NewFlagSet("sqlfmt lint", flag.ExitOnError)
+	rules := fs.String("rules", "", "comma separated rules to run, all rules by default")
+	disable := fs.String("disable", "", "comma separated rules not to run")
+	severities := fs.String("severity", "", "comma separated severities of rules such as select-star=error")
+
+	linter := lint.New(lint.Rules()...)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: sqlfmt lint [flags] [path ...]\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "rules:\n")
+		for _, rule := range linter.Rules() {
+			fmt.Fprintf(os.Stderr, "  %s (%s)\n", rule.Name(), rule.Severity())
+		}
+	}
+	fs.Parse(args)
+
+	if *rules != "" {
+		for _, rule := range linter.Rules() {
+			linter.Disable(rule.Name())
+		}
+		if err := linter.Enable(splitList(*rules)...); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := linter.Disable(splitList(*disable)...); err != nil {
+		log.Fatal(err)
+	}
+	for _, s := range splitList(*severities) {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			log.Fatalf("invalid value %q for -severity: must be rule=severity", s)
+		}
+		severity, err := lint.ParseSeverity(kv[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := linter.SetSeverity(kv[0], severity); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var found bool
+	lintFile := func(path string) {
+		problems, err := lintPath(linter, path)
+		if err != nil {
+			log.Println(err)
+		}
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		found = found || len(problems) > 0
+	}
+
+	for _, path := range fs.Args() {
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !info.IsDir() {
+			lintFile(path)
+			continue
+		}
+		filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+			if err == nil && (isGoFile(info) || isSQLFile(info)) {
+				lintFile(path)
+			}
+			return nil
+		})
+	}
+	if found {
+		os.Exit(1)
+	}
+}
+
+// lintPath checks SQL statements in .go file, or the SQL statement of .sql file
+func lintPath(linter *lint.Linter, path string) ([]lint.Problem, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "ioutil.ReadFile failed")
+	}
+
+	if filepath.Ext(path) != ".sql" {
+		return linter.LintFile(path, src)
+	}
+	problems, err := linter.Lint(string(src))
+	if err != nil {
+		return nil, errors.Wrapf(err, "lint of %s failed", path)
+	}
+	for i := range problems {
+		problems[i].Pos.Filename = path
+	}
+	return problems, nil
+}
+
+func isSQLFile(info os.FileInfo) bool {
+	name := info.Name()
+	return !info.IsDir() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".sql")
+}
+
+// splitList splits comma separated list, returning nil for empty string
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+// reports holds reports of processed files written by -format
+var reports []*fileReport
+
+// fileReport is a report of SQL statements in a file
+type fileReport struct {
+	Filename string          `json:"filename"`
+	Changed  bool            `json:"changed"`
+	Literals []literalReport `json:"literals"`
+	// Error is set if the file could not be processed such as the file with syntax errors
+	Error string `json:"error,omitempty"`
+}
+
+// literalReport is a report of a string literal of SQL statement
+type literalReport struct {
+	Start       position `json:"start"`
+	End         position `json:"end"`
+	Changed     bool     `json:"changed"`
+	Replacement string   `json:"replacement,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// position is a position in a file, whose line and column are 1-based and column is counted in characters
+type position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func newPosition(src []byte, offset int) position {
+	pos := position{Offset: offset, Line: 1, Column: 1}
+	for i := 0; i < offset; {
+		r, size := utf8.DecodeRune(src[i:])
+		if r == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+		i += size
+	}
+	return pos
+}
+
+// reportFile adds the report of SQL statements in src to reports, writing the formatted source if -w is set
+func reportFile(filename string, src []byte) error {
+	report := &fileReport{Filename: filename, Literals: []literalReport{}}
+	reports = append(reports, report)
+
+	ranges, err := targetRanges(filename, src)
+	if err != nil {
+		return err
+	}
+
+	opt := *options
+	opt.ErrorHandler = func(err *sqlfmt.LiteralError) {
+		report.Literals = append(report.Literals, literalReport{
+			Start: newPosition(src, err.Start.Offset),
+			End:   newPosition(src, err.End.Offset),
+			Error: err.Err.Error(),
+		})
+	}
+	edits, err := sqlfmt.EditsRanges(filename, src, ranges, &opt)
+	if err != nil {
+		if _, ok := err.(*sqlfmt.FormatError); ok {
+			report.Error = err.Error()
+			return nil
+		}
+		return errors.Wrap(err, "sqlfmt.EditsRanges failed")
+	}
+
+	for _, e := range edits {
+		literal := literalReport{
+			Start:   newPosition(src, e.Start),
+			End:     newPosition(src, e.End),
+			Changed: string(src[e.Start:e.End]) != e.Text,
+		}
+		if literal.Changed {
+			literal.Replacement = e.Text
+			report.Changed = true
+		}
+		report.Literals = append(report.Literals, literal)
+	}
+	sort.Slice(report.Literals, func(i, j int) bool { return report.Literals[i].Start.Offset < report.Literals[j].Start.Offset })
+
+	if *write && report.Changed {
+		if err := ioutil.WriteFile(filename, sqlfmt.ApplyEdits(src, edits), 0); err != nil {
+			return errors.Wrap(err, "ioutil.WriteFile failed")
+		}
+	}
+	return nil
+}
+
+// reportError sets err to the report of filename, which is added if the file has not been reported
+func reportError(filename string, err error) {
+	for _, report := range reports {
+		if report.Filename == filename {
+			report.Error = err.Error()
+			return
+		}
+	}
+	reports = append(reports, &fileReport{Filename: filename, Literals: []literalReport{}, Error: err.Error()})
+}
+
+// writeReport writes reports in the format given by -format
+func writeReport(w io.Writer) error {
+	var v interface{}
+	switch *outputFormat {
+	case "json":
+		v = &jsonReport{Files: reports}
+	case "sarif":
+		v = newSarifLog(reports)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return errors.Wrap(err, "json.Encode failed")
+	}
+	return nil
+}
+
+type jsonReport struct {
+	Files []*fileReport `json:"files"`
+}
+
+// types of SARIF 2.1.0 used by -format=sarif
+// see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool       sarifTool     `json:"tool"`
+		ColumnKind string        `json:"columnKind"`
+		Results    []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+		Fixes     []sarifFix      `json:"fixes,omitempty"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+		EndLine     int `json:"endLine"`
+		EndColumn   int `json:"endColumn"`
+	}
+	sarifFix struct {
+		Description     sarifMessage          `json:"description"`
+		ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+	}
+	sarifArtifactChange struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Replacements     []sarifReplacement    `json:"replacements"`
+	}
+	sarifReplacement struct {
+		DeletedRegion   sarifRegion  `json:"deletedRegion"`
+		InsertedContent sarifMessage `json:"insertedContent"`
+	}
+)
+
+// rule IDs of SARIF results
+const (
+	ruleUnformatted = "unformatted"
+	ruleFormatError = "format-error"
+)
+
+// newSarifLog returns SARIF log with results of SQL statements which are not formatted or failed to be formatted
+func newSarifLog(reports []*fileReport) *sarifLog {
+	results := []sarifResult{}
+	for _, report := range reports {
+		artifact := sarifArtifactLocation{URI: filepath.ToSlash(report.Filename)}
+		if report.Error != "" {
+			results = append(results, sarifResult{
+				RuleID:    ruleFormatError,
+				Level:     "error",
+				Message:   sarifMessage{Text: report.Error},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}},
+			})
+		}
+		for _, literal := range report.Literals {
+			region := sarifRegion{
+				StartLine:   literal.Start.Line,
+				StartColumn: literal.Start.Column,
+				EndLine:     literal.End.Line,
+				EndColumn:   literal.End.Column,
+			}
+			location := []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact, Region: &region}}}
+			switch {
+			case literal.Error != "":
+				results = append(results, sarifResult{
+					RuleID:    ruleFormatError,
+					Level:     "warning",
+					Message:   sarifMessage{Text: literal.Error},
+					Locations: location,
+				})
+			case literal.Changed:
+				results = append(results, sarifResult{
+					RuleID:    ruleUnformatted,
+					Level:     "warning",
+					Message:   sarifMessage{Text: "SQL statement is not formatted by sqlfmt"},
+					Locations: location,
+					Fixes: []sarifFix{{
+						Description: sarifMessage{Text: "Format SQL statement"},
+						ArtifactChanges: []sarifArtifactChange{{
+							ArtifactLocation: artifact,
+							Replacements:     []sarifReplacement{{DeletedRegion: region, InsertedContent: sarifMessage{Text: literal.Replacement}}},
+						}},
+					}},
+				})
+			}
+		}
+	}
+
+	return &sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "sqlfmt",
+				InformationURI: "https://github.com/kanmu/go-sqlfmt",
+				Rules: []sarifRule{
+					{ID: ruleUnformatted, ShortDescription: sarifMessage{Text: "SQL statement is not formatted"}},
+					{ID: ruleFormatError, ShortDescription: sarifMessage{Text: "SQL statement failed to be formatted"}},
+				},
+			}},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// reportTestingSrc has an unformatted SQL statement at line 4 and a malformed one at line 5
+const reportTestingSrc = "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Query(`select xxx from (xxx`)\n}\n"
+
+func TestNewPosition(t *testing.T) {
+	src := []byte("ab\nあいc\n")
+	tests := []struct {
+		offset int
+		want   position
+	}{
+		{offset: 0, want: position{Offset: 0, Line: 1, Column: 1}},
+		{offset: 2, want: position{Offset: 2, Line: 1, Column: 3}},
+		{offset: 3, want: position{Offset: 3, Line: 2, Column: 1}},
+		// columns are counted in characters, not in bytes
+		{offset: 9, want: position{Offset: 9, Line: 2, Column: 3}},
+		{offset: 11, want: position{Offset: 11, Line: 3, Column: 1}},
+	}
+	for _, tt := range tests {
+		if got := newPosition(src, tt.offset); got != tt.want {
+			t.Errorf("offset %d: want %#v, got %#v", tt.offset, tt.want, got)
+		}
+	}
+}
+
+func TestReportFile(t *testing.T) {
+	defer func() { reports = nil }()
+
+	if err := reportFile("main.go", []byte(reportTestingSrc)); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	reportError("missing.go", errors.New("no such file"))
+
+	if len(reports) != 2 {
+		t.Fatalf("want 2 reports, got %d", len(reports))
+	}
+	report := reports[0]
+	if report.Filename != "main.go" || !report.Changed || report.Error != "" {
+		t.Errorf("want changed main.go without error, got %#v", report)
+	}
+	if len(report.Literals) != 2 {
+		t.Fatalf("want 2 literals, got %#v", report.Literals)
+	}
+	formatted, malformed := report.Literals[0], report.Literals[1]
+	if want := (position{Offset: 38, Line: 4, Column: 11}); formatted.Start != want {
+		t.Errorf("want %#v, got %#v", want, formatted.Start)
+	}
+	if !formatted.Changed || formatted.Replacement == "" || formatted.Error != "" {
+		t.Errorf("want changed literal with replacement, got %#v", formatted)
+	}
+	if malformed.Start.Line != 5 || malformed.Changed || malformed.Error == "" {
+		t.Errorf("want literal with error at line 5, got %#v", malformed)
+	}
+	if reports[1].Filename != "missing.go" || reports[1].Error != "no such file" {
+		t.Errorf("want missing.go with error, got %#v", reports[1])
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	defer func(format string) { *outputFormat = format }(*outputFormat)
+	defer func() { reports = nil }()
+
+	if err := reportFile("main.go", []byte(reportTestingSrc)); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	reportError("missing.go", errors.New("no such file"))
+
+	t.Run("json", func(t *testing.T) {
+		*outputFormat = "json"
+		var buf bytes.Buffer
+		if err := writeReport(&buf); err != nil {
+			t.Fatalf("should be nil, got %v", err)
+		}
+		var got jsonReport
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("should be nil, got %v", err)
+		}
+		if len(got.Files) != 2 || got.Files[0].Filename != "main.go" || got.Files[1].Error != "no such file" {
+			t.Errorf("want reports of main.go and missing.go, got %s", buf.String())
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		*outputFormat = "sarif"
+		var buf bytes.Buffer
+		if err := writeReport(&buf); err != nil {
+			t.Fatalf("should be nil, got %v", err)
+		}
+		var got sarifLog
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("should be nil, got %v", err)
+		}
+		if got.Version != "2.1.0" || len(got.Runs) != 1 {
+			t.Fatalf("want a run of SARIF 2.1.0, got %s", buf.String())
+		}
+		results := got.Runs[0].Results
+		if len(results) != 3 {
+			t.Fatalf("want 3 results, got %s", buf.String())
+		}
+
+		unformatted := results[0]
+		if unformatted.RuleID != ruleUnformatted || len(unformatted.Fixes) != 1 {
+			t.Errorf("want unformatted result with a fix, got %#v", unformatted)
+		}
+		region := unformatted.Locations[0].PhysicalLocation.Region
+		if want := (sarifRegion{StartLine: 4, StartColumn: 11, EndLine: 4, EndColumn: 32}); region == nil || *region != want {
+			t.Errorf("want %#v, got %#v", want, region)
+		}
+		if got := unformatted.Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion; got != *region {
+			t.Errorf("fix should replace %#v, got %#v", *region, got)
+		}
+
+		if results[1].RuleID != ruleFormatError || results[1].Level != "warning" {
+			t.Errorf("want format error of the literal, got %#v", results[1])
+		}
+		if results[2].RuleID != ruleFormatError || results[2].Level != "error" || results[2].Locations[0].PhysicalLocation.Region != nil {
+			t.Errorf("want format error of missing.go without region, got %#v", results[2])
+		}
+	})
+}
@@ -11,27 +11,44 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/kanmu/go-sqlfmt/sqlfmt"
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lsp"
 )
 
 var (
 	// main operation modes
-	list    = flag.Bool("l", false, "list files whose formatting differs from goreturns's")
-	write   = flag.Bool("w", false, "write result to (source) file instead of stdout")
-	doDiff  = flag.Bool("d", false, "display diffs instead of rewriting files")
-	options = &sqlfmt.Options{}
+	list         = flag.Bool("l", false, "list files whose formatting differs from goreturns's")
+	write        = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	doDiff       = flag.Bool("d", false, "display diffs instead of rewriting files")
+	indent       = flag.String("indent", "", "indent SQL statements relative to the surrounding Go code with tabs if \"auto\"")
+	offset       = flag.Int("offset", -1, "format only SQL statements at the byte offset such as the cursor position")
+	lines        = flag.String("lines", "", "format only SQL statements overlapping the line range such as 10:40")
+	outputFormat = flag.String("format", "", "write a report of SQL statements in \"json\" or \"sarif\" instead of the formatted source")
+	base         = flag.String("diff-base", "", "format only SQL statements overlapping lines changed from the git revision such as origin/main")
+	compact      = flag.Bool("compact", false, "write SQL statements on one line with single spaces")
+	options      = &sqlfmt.Options{}
+
+	// line range given by -lines
+	fromLine, toLine int
 )
 
 func init() {
 	flag.IntVar(&options.Distance, "distance", 0, "write the distance from the edge to the begin of SQL statements")
+	flag.BoolVar(&options.Verify, "verify", false, "verify that formatting the result again does not change it")
+	flag.BoolVar(&options.FoldConcat, "fold-concat", false, "fold concatenated string literals into a raw string literal and format it")
+	flag.BoolVar(&options.ConvertStrings, "convert-strings", false, "format interpreted string literals and convert them into raw string literals")
 }
 
 func usage() {
 	fmt.Fprintf(os.Stderr, "usage: sqlfmt [flags] [path ...]\n")
+	fmt.Fprintf(os.Stderr, "       sqlfmt lsp [flags]\n")
+	fmt.Fprintf(os.Stderr, "       sqlfmt lint [flags] [path ...]\n")
+	fmt.Fprintf(os.Stderr, "       sqlfmt fingerprint [path ...]\n")
 	flag.PrintDefaults()
 }
 
@@ -45,8 +62,7 @@ func visitFile(path string, info os.FileInfo, err error) error {
 		err = processFile(path, nil, os.Stdout)
 	}
 	if err != nil {
-		processError(errors.Wrap(err, "visit file failed"))
-
+		processError(path, errors.Wrap(err, "visit file failed"))
 	}
 	return nil
 }
@@ -69,7 +85,11 @@ func processFile(filename string, in io.Reader, out io.Writer) error {
 		return errors.Wrap(err, "ioutil.ReadAll failed")
 	}
 
-	res, err := sqlfmt.Process(filename, src, options)
+	if *outputFormat != "" {
+		return reportFile(filename, src)
+	}
+
+	res, err := process(filename, src)
 	if err != nil {
 		return errors.Wrap(err, "sqlfmt.Process failed")
 	}
@@ -101,17 +121,163 @@ func processFile(filename string, in io.Reader, out io.Writer) error {
 	return nil
 }
 
+// process formats SQL statements in src, which are restricted by -offset, -lines or -diff-base if set
+func process(filename string, src []byte) ([]byte, error) {
+	ranges, err := targetRanges(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	// file without changes from -diff-base is not parsed
+	if len(ranges) == 0 {
+		return src, nil
+	}
+	return sqlfmt.ProcessRanges(filename, src, ranges, options)
+}
+
+// targetRanges returns byte ranges of src in which SQL statements are formatted
+func targetRanges(filename string, src []byte) ([]sqlfmt.Range, error) {
+	switch {
+	case *base != "":
+		ranges, err := changedRanges(*base, filename, src)
+		if err != nil {
+			return nil, errors.Wrap(err, "changedRanges failed")
+		}
+		return ranges, nil
+	case *offset >= 0:
+		return []sqlfmt.Range{{Start: *offset, End: *offset}}, nil
+	case *lines != "":
+		start, end := lineOffsets(src, fromLine, toLine)
+		return []sqlfmt.Range{{Start: start, End: end}}, nil
+	}
+	return []sqlfmt.Range{{Start: 0, End: len(src)}}, nil
+}
+
+// parseLines parses line range such as 10:40, whose lines are 1-based and inclusive
+func parseLines(s string) (from, to int, err error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid value %q for -lines: must be from:to", s)
+	}
+	if from, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid value %q for -lines: %v", s, err)
+	}
+	if to, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid value %q for -lines: %v", s, err)
+	}
+	if from < 1 || from > to {
+		return 0, 0, fmt.Errorf("invalid value %q for -lines: must be 1 <= from <= to", s)
+	}
+	return from, to, nil
+}
+
+// lineOffsets returns byte offsets of the beginning of line from and the end of line to in src
+func lineOffsets(src []byte, from, to int) (start, end int) {
+	start, end = len(src), len(src)
+	line := 1
+	for i := 0; i <= len(src); i++ {
+		if line == from && start == len(src) {
+			start = i
+		}
+		if i == len(src) {
+			break
+		}
+		if src[i] == '\n' {
+			if line == to {
+				return start, i
+			}
+			line++
+		}
+	}
+	return start, end
+}
+
 func sqlfmtMain() {
 	flag.Usage = usage
-	flag.Parse()
+
+	// subcommands are dispatched by the first argument before flags
+	// so that directories with the same names are formatted such as sqlfmt -w lint
+	args := os.Args[1:]
+	var isLSP bool
+	if len(args) > 0 {
+		switch args[0] {
+		case "lint":
+			lintMain(args[1:])
+			return
+		case "fingerprint":
+			fingerprintMain(args[1:])
+			return
+		case "lsp":
+			isLSP, args = true, args[1:]
+		}
+	}
+	flag.CommandLine.Parse(args)
+
+	switch *indent {
+	case "":
+	case "auto":
+		options.AutoIndent = true
+	default:
+		log.Fatalf("invalid value %q for -indent: only \"auto\" is supported", *indent)
+	}
+
+	if *compact {
+		options.Style = sqlfmt.Compact
+	}
+
+	switch *outputFormat {
+	case "", "json", "sarif":
+	default:
+		log.Fatalf("invalid value %q for -format: only \"json\" and \"sarif\" are supported", *outputFormat)
+	}
+	if *outputFormat != "" && (*list || *doDiff) {
+		log.Fatal("can not use -l or -d with -format")
+	}
+	if isLSP && (*outputFormat != "" || *list || *write || *doDiff || *offset >= 0 || *lines != "" || *base != "") {
+		log.Fatal("can not use -format, -l, -w, -d, -offset, -lines or -diff-base with lsp")
+	}
+	if isLSP && flag.NArg() > 0 {
+		log.Fatal("can not use paths with lsp")
+	}
+
+	if *offset >= 0 && *lines != "" || *base != "" && (*offset >= 0 || *lines != "") {
+		log.Fatal("can not use -offset, -lines and -diff-base at the same time")
+	}
+	if *lines != "" {
+		var err error
+		if fromLine, toLine, err = parseLines(*lines); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if (*offset >= 0 || *lines != "") && flag.NArg() > 1 {
+		log.Fatal("can not use -offset or -lines with multiple files")
+	}
+
+	if *outputFormat != "" {
+		defer func() {
+			if err := writeReport(os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// the editor is speaking Language Server Protocol over stdio
+	if isLSP {
+		if err := lsp.NewServer(options).Serve(os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	// the user is piping their source into go-sqlfmt
 	if flag.NArg() == 0 {
 		if *write {
 			log.Fatal("can not use -w while using pipeline")
 		}
+		if *base != "" {
+			log.Fatal("can not use -diff-base while using pipeline")
+		}
 		if err := processFile("<standard input>", os.Stdin, os.Stdout); err != nil {
-			processError(errors.Wrap(err, "processFile failed"))
+			processError("<standard input>", errors.Wrap(err, "processFile failed"))
 		}
 		return
 	}
@@ -120,18 +286,16 @@ func sqlfmtMain() {
 		path := flag.Arg(i)
 		switch dir, err := os.Stat(path); {
 		case err != nil:
-			processError(err)
+			processError(path, err)
 		case dir.IsDir():
+			if *offset >= 0 || *lines != "" {
+				log.Fatal("can not use -offset or -lines with directory")
+			}
 			walkDir(path)
 		default:
-			info, err := os.Stat(path)
-			if err != nil {
-				processError(err)
-			}
-			if isGoFile(info) {
-				err = processFile(path, nil, os.Stdout)
-				if err != nil {
-					processError(err)
+			if isGoFile(dir) {
+				if err := processFile(path, nil, os.Stdout); err != nil {
+					processError(path, err)
 				}
 			}
 		}
@@ -170,7 +334,13 @@ func diff(b1, b2 []byte) (data []byte, err error) {
 	return
 }
 
-func processError(err error) {
+// processError logs err of filename, which is added to the report instead if -format is set
+// so that the report is written even if some files can not be processed
+func processError(filename string, err error) {
+	if *outputFormat != "" {
+		reportError(filename, err)
+		return
+	}
 	switch err.(type) {
 	case *sqlfmt.FormatError:
 		log.Println(err)
@@ -0,0 +1,92 @@
+// Package analyzer provides the analyzer reporting SQL statements which are not formatted by sqlfmt
+// it runs in go vet -vettool, golangci-lint and the other drivers of golang.org/x/tools/go/analysis
+package analyzer
+
+import (
+	"os"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/analysis"
+)
+
+const doc = `report SQL statements which are not formatted by sqlfmt
+
+SQL statements in string literals passed to Query, QueryRow and Exec functions
+are formatted in the same way as sqlfmt, and the differences are reported
+with suggested fixes, which can be applied with -fix.`
+
+// Analyzer reports SQL statements which are not formatted by sqlfmt
+var Analyzer = &analysis.Analyzer{
+	Name: "sqlfmt",
+	Doc:  doc,
+	Run:  run,
+}
+
+// options for formatting, which are set by flags of Analyzer
+var (
+	options = &sqlfmt.Options{}
+	indent  string
+	compact bool
+)
+
+func init() {
+	fs := &Analyzer.Flags
+	fs.IntVar(&options.Distance, "distance", 0, "write the distance from the edge to the begin of SQL statements")
+	fs.BoolVar(&options.FoldConcat, "fold-concat", false, "fold concatenated string literals into a raw string literal and format it")
+	fs.BoolVar(&options.ConvertStrings, "convert-strings", false, "format interpreted string literals and convert them into raw string literals")
+	fs.StringVar(&indent, "indent", "", "indent SQL statements relative to the surrounding Go code with tabs if \"auto\"")
+	fs.BoolVar(&compact, "compact", false, "write SQL statements on one line with single spaces")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	opt := *options
+	switch indent {
+	case "":
+	case "auto":
+		opt.AutoIndent = true
+	default:
+		return nil, errors.Errorf("invalid value %q for -indent: only \"auto\" is supported", indent)
+	}
+	if compact {
+		opt.Style = sqlfmt.Compact
+	}
+	// SQL statements which failed to be formatted are left to sqlfmt, as Process logs them
+	opt.ErrorHandler = func(*sqlfmt.LiteralError) {}
+
+	for _, f := range pass.Files {
+		file := pass.Fset.File(f.Pos())
+		if file == nil {
+			continue
+		}
+		src, err := os.ReadFile(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		// the file is skipped if it differs from the parsed one such as files generated by cgo
+		if len(src) != file.Size() {
+			continue
+		}
+
+		edits, err := sqlfmt.Edits(file.Name(), src, &opt)
+		if err != nil {
+			continue
+		}
+		for _, e := range edits {
+			if string(src[e.Start:e.End]) == e.Text {
+				continue
+			}
+			pos, end := file.Pos(e.Start), file.Pos(e.End)
+			pass.Report(analysis.Diagnostic{
+				Pos:     pos,
+				End:     end,
+				Message: "SQL statement is not formatted",
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   "Format SQL statement",
+					TextEdits: []analysis.TextEdit{{Pos: pos, End: end, NewText: []byte(e.Text)}},
+				}},
+			})
+		}
+	}
+	return nil, nil
+}
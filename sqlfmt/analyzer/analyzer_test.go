@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+	"golang.org/x/tools/go/analysis"
+)
+
+// TestAnalyzer runs Analyzer on testdata/a.go and compares the result of suggested fixes with testdata/a.go.golden
+// the pass is made without loading packages because Analyzer does not use type information
+func TestAnalyzer(t *testing.T) {
+	filename := filepath.Join("testdata", "a.go")
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var diagnostics []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Analyzer: Analyzer,
+		Fset:     fset,
+		Files:    []*ast.File{f},
+		Report:   func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) },
+	}
+	if _, err := Analyzer.Run(pass); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("want 1 diagnostic, got %#v", diagnostics)
+	}
+	if pos, want := fset.Position(diagnostics[0].Pos), "testdata/a.go:6:11"; pos.String() != want {
+		t.Errorf("want %s, got %s", want, pos)
+	}
+
+	var edits []sqlfmt.Edit
+	for _, d := range diagnostics {
+		for _, fix := range d.SuggestedFixes {
+			for _, e := range fix.TextEdits {
+				edits = append(edits, sqlfmt.Edit{Start: fset.Position(e.Pos).Offset, End: fset.Position(e.End).Offset, Text: string(e.NewText)})
+			}
+		}
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filename + ".golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sqlfmt.ApplyEdits(src, edits); string(got) != string(want) {
+		t.Errorf("\nwant %#v, \ngot %#v", string(want), string(got))
+	}
+}
+
+func TestAnalyzerFlags(t *testing.T) {
+	Analyzer.Flags.SetOutput(io.Discard)
+	if err := Analyzer.Flags.Parse([]string{"-xxx"}); err == nil {
+		t.Errorf("should be error, got nil")
+	}
+	if err := Analyzer.Flags.Parse([]string{"-distance=0", "-compact=false"}); err != nil {
+		t.Errorf("should be nil, got %v", err)
+	}
+}
+
+func TestAnalyzerInvalidIndent(t *testing.T) {
+	defer func(v string) { indent = v }(indent)
+	indent = "xxx"
+	pass := &analysis.Pass{Analyzer: Analyzer, Fset: token.NewFileSet()}
+	if _, err := Analyzer.Run(pass); err == nil {
+		t.Errorf("should be error, got nil")
+	}
+}
@@ -0,0 +1,14 @@
+package a
+
+import "database/sql"
+
+func query(db *sql.DB) {
+	db.Query(`select xxx from xxx where xxx = $1`, 1)
+	db.Exec(`
+UPDATE
+  xxx
+SET
+  xxx = 1`)
+	db.Exec(`select (xxx`)
+	db.Query("select xxx from xxx")
+}
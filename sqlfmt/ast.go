@@ -1,13 +1,16 @@
 package sqlfmt
 
 import (
-	"fmt"
+	"bytes"
 	"go/ast"
 	"go/token"
-	"log"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
 	"github.com/kanmu/go-sqlfmt/sqlfmt/parser/group"
+	"github.com/pkg/errors"
 )
 
 // sqlfmt retrieves all strings from "Query" and "QueryRow" and "Exec" functions in .go file
@@ -17,34 +20,294 @@ const (
 	EXEC     = "Exec"
 )
 
-// replaceAst replace ast node with formatted SQL statement
-func replaceAst(f *ast.File, fset *token.FileSet, options *Options) {
-	ast.Inspect(f, func(n ast.Node) bool {
+// Edit replaces src[Start:End] with Text
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Range is the byte range between Start and End in src
+type Range struct {
+	Start int
+	End   int
+}
+
+func newEdit(fset *token.FileSet, start, end token.Pos, text string) Edit {
+	return Edit{Start: fset.Position(start).Offset, End: fset.Position(end).Offset, Text: text}
+}
+
+// collectEdits returns edits replacing SQL statements in Query, QueryRow and Exec functions with formatted ones
+// only SQL statements overlapping any of ranges are formatted
+func collectEdits(f *ast.File, fset *token.FileSet, src []byte, ranges []Range, options *Options) []Edit {
+	var edits []Edit
+	inspectCalls(f, func(x *ast.CallExpr) {
+		if !overlaps(fset, x.Args[0], ranges) {
+			return
+		}
+		// SQL statement is indented one level deeper than the line of the function call
+		var indent string
+		if options.AutoIndent {
+			indent = lineIndent(src, fset, x.Pos()) + "\t"
+		}
+		if concat, ok := x.Args[0].(*ast.BinaryExpr); ok && concat.Op == token.ADD && options.FoldConcat {
+			edits = append(edits, replaceConcat(concat, fset, options, indent)...)
+			return
+		}
+		if arg, ok := x.Args[0].(*ast.BasicLit); ok {
+			if e, ok := replaceLit(arg, fset, options, indent); ok {
+				edits = append(edits, e)
+			}
+		}
+		// template of fmt.Sprintf such as db.Query(fmt.Sprintf(`SELECT xxx FROM %s`, table))
+		if call, ok := x.Args[0].(*ast.CallExpr); ok && isSprintf(call) && len(call.Args) > 0 {
+			if arg, ok := call.Args[0].(*ast.BasicLit); ok {
+				opt := *options
+				opt.PrintfVerbs = true
+				if e, ok := replaceLit(arg, fset, &opt, indent); ok {
+					edits = append(edits, e)
+				}
+			}
+		}
+	})
+	return edits
+}
+
+// inspectCalls calls f with each call of Query, QueryRow and Exec functions in file
+func inspectCalls(file *ast.File, f func(call *ast.CallExpr)) {
+	ast.Inspect(file, func(n ast.Node) bool {
 		if x, ok := n.(*ast.CallExpr); ok {
 			if fun, ok := x.Fun.(*ast.SelectorExpr); ok {
 				funcName := fun.Sel.Name
-				if funcName == QUERY || funcName == QUERYROW || funcName == EXEC {
-					// not for parsing url.Query
-					if len(x.Args) > 0 {
-						if arg, ok := x.Args[0].(*ast.BasicLit); ok {
-							sqlStmt := arg.Value
-							if !strings.HasPrefix(sqlStmt, "`") {
-								return true
-							}
-							src := strings.Trim(sqlStmt, "`")
-							res, err := Format(src, options)
-							if err != nil {
-								log.Println(fmt.Sprintf("Format failed at %s: %v", fset.Position(arg.Pos()), err))
-								return true
-							}
-							// FIXME
-							// more elegant
-							arg.Value = "`" + res + strings.Repeat(group.WhiteSpace, options.Distance) + "`"
-						}
-					}
+				// not for parsing url.Query
+				if (funcName == QUERY || funcName == QUERYROW || funcName == EXEC) && len(x.Args) > 0 {
+					f(x)
 				}
 			}
 		}
 		return true
 	})
 }
+
+// overlaps returns true if node overlaps any of ranges
+// node touching the range such as the literal just before the cursor is regarded as overlapping
+func overlaps(fset *token.FileSet, node ast.Node, ranges []Range) bool {
+	start, end := fset.Position(node.Pos()).Offset, fset.Position(node.End()).Offset
+	for _, r := range ranges {
+		if start <= r.End && r.Start <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyEdits returns src whose byte ranges are replaced by edits
+// edits must be sorted by Start and must not overlap
+func ApplyEdits(src []byte, edits []Edit) []byte {
+	var (
+		buf    bytes.Buffer
+		offset int
+	)
+	for _, e := range edits {
+		buf.Write(src[offset:e.Start])
+		buf.WriteString(e.Text)
+		offset = e.End
+	}
+	buf.Write(src[offset:])
+	return buf.Bytes()
+}
+
+// replaceLit returns Edit replacing raw string literal with formatted SQL statement
+// if ConvertStrings is true, interpreted string literal is also replaced with raw string literal
+func replaceLit(arg *ast.BasicLit, fset *token.FileSet, options *Options, indent string) (Edit, bool) {
+	var src string
+	switch sqlStmt := arg.Value; {
+	case strings.HasPrefix(sqlStmt, "`"):
+		src = strings.Trim(sqlStmt, "`")
+	case strings.HasPrefix(sqlStmt, `"`) && options.ConvertStrings:
+		v, err := strconv.Unquote(sqlStmt)
+		if err != nil || !canBeRawString(v) {
+			return Edit{}, false
+		}
+		src = v
+	default:
+		return Edit{}, false
+	}
+	res, err := formatLit(src, options, indent)
+	if err != nil {
+		options.handleError(&LiteralError{Start: fset.Position(arg.Pos()), End: fset.Position(arg.End()), Err: err})
+		return Edit{}, false
+	}
+	return newEdit(fset, arg.Pos(), arg.End(), "`"+res+"`"), true
+}
+
+// formatLit formats src in string literal, returning the value of raw string literal
+// if indent is not empty, each line of SQL statement and closing back quote are indented by indent
+// otherwise, closing back quote is indented by Distance
+// SQL statement in Compact style is written on the line of the string literal without indentation
+func formatLit(src string, options *Options, indent string) (string, error) {
+	if options.Style == Compact {
+		return Format(src, options)
+	}
+	if indent == "" {
+		res, err := Format(src, options)
+		if err != nil {
+			return "", err
+		}
+		return res + strings.Repeat(group.WhiteSpace, options.Distance), nil
+	}
+
+	opt := *options
+	opt.Distance = 0
+	res, err := Format(src, &opt)
+	if err != nil {
+		return "", err
+	}
+	// indentation must not change string literals spanning lines
+	res = putIndent(res, indent)
+	if err := checkEquivalence(src, res, &opt); err != nil {
+		return "", errors.Wrap(err, "the indented statement has diffed from the source")
+	}
+	return res + indent, nil
+}
+
+// lineIndent returns whitespaces at the beginning of the line where pos is
+func lineIndent(src []byte, fset *token.FileSet, pos token.Pos) string {
+	file := fset.File(pos)
+	if file == nil {
+		return ""
+	}
+	start := file.Offset(file.LineStart(file.Line(pos)))
+	end := start
+	for end < len(src) && (src[end] == '\t' || src[end] == ' ') {
+		end++
+	}
+	return string(src[start:end])
+}
+
+// canBeRawString returns true if s can be written in raw string literal without changing its value
+// raw string literal can not contain back quotes, and carriage returns in it are discarded
+// NUL and invalid UTF-8 can not be written in Go source
+func canBeRawString(s string) bool {
+	return !strings.ContainsAny(s, "`\r\x00") && utf8.ValidString(s)
+}
+
+// isSprintf returns true if call is fmt.Sprintf
+func isSprintf(call *ast.CallExpr) bool {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := fun.X.(*ast.Ident)
+	return ok && pkg.Name == "fmt" && fun.Sel.Name == "Sprintf"
+}
+
+// replaceConcat returns edits formatting SQL statement built from concatenated string literals such as "SELECT xxx " + "FROM xxx"
+// adjacent string literals are folded into a raw string literal and formatted
+// if the concatenation contains non-constant values, each fragment of string literals which is SQL statement is formatted
+func replaceConcat(concat *ast.BinaryExpr, fset *token.FileSet, options *Options, indent string) []Edit {
+	operands := flattenConcat(concat)
+
+	var (
+		edits []Edit
+		lits  []*ast.BasicLit
+	)
+	isConstant := true
+	for _, operand := range operands {
+		if lit, ok := operand.(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+			isConstant = false
+			break
+		}
+	}
+
+	for i := 0; i <= len(operands); i++ {
+		if i < len(operands) {
+			if lit, ok := operands[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				lits = append(lits, lit)
+				continue
+			}
+		}
+		if len(lits) > 0 {
+			isFirst, isLast := lits[0] == operands[0], i == len(operands)
+			if res, ok := foldLits(lits, fset, options, indent, isConstant, isFirst, isLast); ok {
+				edits = append(edits, newEdit(fset, lits[0].Pos(), lits[len(lits)-1].End(), res))
+			}
+			lits = nil
+		}
+	}
+	return edits
+}
+
+// flattenConcat returns operands of concatenation such as a + b + c
+func flattenConcat(expr ast.Expr) []ast.Expr {
+	if concat, ok := expr.(*ast.BinaryExpr); ok && concat.Op == token.ADD {
+		return append(flattenConcat(concat.X), flattenConcat(concat.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// foldLits folds string literals into a formatted raw string literal
+// it returns false if the folded value is not SQL statement or can not be a raw string literal
+// fragments between non-constant values are formatted only when they are separated from them by whitespaces
+func foldLits(lits []*ast.BasicLit, fset *token.FileSet, options *Options, indent string, isConstant, isFirst, isLast bool) (string, bool) {
+	var src string
+	for _, lit := range lits {
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", false
+		}
+		src += v
+	}
+	if !isConstant && (!isFirst && !startsWithSpace(src) || !isLast && !endsWithSpace(src) || !isCompleteFragment(src)) {
+		return "", false
+	}
+
+	if !canBeRawString(src) {
+		return "", false
+	}
+
+	res, err := formatLit(src, options, indent)
+	if err != nil {
+		if isConstant {
+			options.handleError(&LiteralError{Start: fset.Position(lits[0].Pos()), End: fset.Position(lits[len(lits)-1].End()), Err: err})
+		}
+		return "", false
+	}
+
+	// the fragment followed by non-constant value ends with new line
+	// in Compact style, the fragment is separated from non-constant values by spaces
+	switch {
+	case options.Style == Compact:
+		if !isFirst {
+			res = group.WhiteSpace + res
+		}
+		if !isLast {
+			res += group.WhiteSpace
+		}
+	case !isLast && !strings.HasSuffix(strings.TrimRight(res, " \t"), "\n"):
+		res += "\n"
+	}
+	return "`" + res + "`", true
+}
+
+func startsWithSpace(s string) bool {
+	return strings.TrimLeft(s, " \t\n") != s
+}
+
+func endsWithSpace(s string) bool {
+	return strings.TrimRight(s, " \t\n") != s
+}
+
+// isCompleteFragment returns true if src ends with a token which completes clause
+// such as "SELECT xxx FROM xxx", not "SELECT xxx FROM"
+func isCompleteFragment(src string) bool {
+	tokens, err := lexer.NewTokenizer(src).GetTokens()
+	if err != nil || len(tokens) < 2 {
+		return false
+	}
+	switch tokens[len(tokens)-2].Type {
+	case lexer.IDENT, lexer.STRING, lexer.PLACEHOLDER, lexer.ENDPARENTHESIS, lexer.NULL, lexer.END, lexer.ASC, lexer.DESC, lexer.ROWS, lexer.ROW:
+		return true
+	}
+	return false
+}
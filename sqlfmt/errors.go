@@ -2,6 +2,7 @@ package sqlfmt
 
 import (
 	"fmt"
+	"go/token"
 )
 
 // FormatError is an error that occurred while sqlfmt.Process
@@ -12,3 +13,14 @@ type FormatError struct {
 func (e *FormatError) Error() string {
 	return fmt.Sprint(e.msg)
 }
+
+// LiteralError is an error that occurred while formatting SQL statement in the string literal between Start and End
+type LiteralError struct {
+	Start token.Position
+	End   token.Position
+	Err   error
+}
+
+func (e *LiteralError) Error() string {
+	return fmt.Sprintf("Format failed at %s: %v", e.Start, e.Err)
+}
@@ -16,8 +16,15 @@ import (
 // 1: tokenize src
 // 2: parse tokens by SQL clause group
 // 3: for each clause group (Reindenter), add indentation or new line in the correct position
-func Format(src string, options *Options) (string, error) {
-	t := lexer.NewTokenizer(src)
+// if formatting malformed statement panics, the panic is recovered and src is returned with an error
+func Format(src string, options *Options) (res string, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			res, err = src, &panicError{value: p}
+		}
+	}()
+
+	t := newTokenizer(src, options)
 	tokens, err := t.GetTokens()
 	if err != nil {
 		return src, errors.Wrap(err, "Tokenize failed")
@@ -28,17 +35,56 @@ func Format(src string, options *Options) (string, error) {
 		return src, errors.Wrap(err, "ParseTokens failed")
 	}
 
-	res, err := getFormattedStmt(rs, options.Distance)
-	if err != nil {
+	if options.Style == Compact {
+		if res, err = getCompactStmt(rs); err != nil {
+			return src, errors.Wrap(err, "getCompactStmt failed")
+		}
+	} else if res, err = getFormattedStmt(rs, options.Distance); err != nil {
 		return src, errors.Wrap(err, "getFormattedStmt failed")
 	}
 
-	if !compare(src, res) {
-		return src, fmt.Errorf("the formatted statement has diffed from the source")
+	if err := checkEquivalence(src, res, options); err != nil {
+		return src, errors.Wrap(err, "the formatted statement has diffed from the source")
+	}
+
+	if options.Verify {
+		if err := verify(res, options); err != nil {
+			return src, errors.Wrap(err, "verify failed")
+		}
 	}
 	return res, nil
 }
 
+// panicError is an error made from the panic recovered in Format
+type panicError struct {
+	value interface{}
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("Format panicked: %v", e.value)
+}
+
+// verify returns an error if formatting res again changes it
+func verify(res string, options *Options) error {
+	opt := *options
+	opt.Verify = false
+
+	again, err := Format(res, &opt)
+	if err != nil {
+		return errors.Wrap(err, "Format of the formatted statement failed")
+	}
+	if again != res {
+		return fmt.Errorf("the formatted statement is not idempotent: %#v became %#v", res, again)
+	}
+	return nil
+}
+
+func newTokenizer(src string, options *Options) *lexer.Tokenizer {
+	t := lexer.NewTokenizer(src)
+	t.PrintfVerbs = options.PrintfVerbs
+	return t
+}
+
 func getFormattedStmt(rs []group.Reindenter, distance int) (string, error) {
 	var buf bytes.Buffer
 
@@ -54,6 +100,62 @@ func getFormattedStmt(rs []group.Reindenter, distance int) (string, error) {
 	return buf.String(), nil
 }
 
+// getCompactStmt writes tokens of clause groups on one line separated by single spaces
+// statement with line comment is not written, because the comment would comment out the rest of the line
+func getCompactStmt(rs []group.Reindenter) (string, error) {
+	var (
+		buf  strings.Builder
+		prev lexer.Token
+	)
+	var write func(rs []group.Reindenter) error
+	write = func(rs []group.Reindenter) error {
+		for _, r := range rs {
+			tok, ok := r.(lexer.Token)
+			if !ok {
+				if err := write(group.Elements(r)); err != nil {
+					return err
+				}
+				continue
+			}
+			if tok.Type == lexer.EOF {
+				continue
+			}
+			if isLineComment(tok) {
+				return fmt.Errorf("can not write line comment %#v on one line", tok.Value)
+			}
+			if buf.Len() > 0 && needsSpace(prev, tok) {
+				buf.WriteString(group.WhiteSpace)
+			}
+			buf.WriteString(tok.Value)
+			prev = tok
+		}
+		return nil
+	}
+	if err := write(rs); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// isLineComment returns true if tok starts line comment, which is scanned as an ident such as "--" and "--xxx"
+func isLineComment(tok lexer.Token) bool {
+	return tok.Type == lexer.IDENT && strings.HasPrefix(tok.Value, "--")
+}
+
+// putIndent puts indent at the beginning of each line except empty lines
+func putIndent(src string, indent string) string {
+	scanner := bufio.NewScanner(strings.NewReader(src))
+
+	var result string
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			result += indent + line
+		}
+		result += "\n"
+	}
+	return result
+}
+
 func putDistance(src string, distance int) string {
 	scanner := bufio.NewScanner(strings.NewReader(src))
 
@@ -64,25 +166,41 @@ func putDistance(src string, distance int) string {
 	return result
 }
 
-// returns false if the value of formatted statement  (without any space) differs from source statement
-func compare(src string, res string) bool {
-	before := removeSpace(src)
-	after := removeSpace(res)
+// checkEquivalence returns an error describing the first token of res which differs from the token of src
+// tokens are compared by type and value, and values of keywords are compared case-insensitively
+func checkEquivalence(src string, res string, options *Options) error {
+	before, err := newTokenizer(src, options).GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Tokenize of the source failed")
+	}
+	after, err := newTokenizer(res, options).GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Tokenize of the formatted statement failed")
+	}
 
-	if v := strings.Compare(before, after); v != 0 {
-		return false
+	for i := 0; i < len(before) || i < len(after); i++ {
+		switch {
+		case i >= len(before):
+			return fmt.Errorf("token %d: %#v is added", i, after[i].Value)
+		case i >= len(after):
+			return fmt.Errorf("token %d: %#v is removed", i, before[i].Value)
+		case before[i].Value == after[i].Value && before[i].Type != after[i].Type:
+			return fmt.Errorf("token %d: type of %#v has changed", i, before[i].Value)
+		case !isEquivalentToken(before[i], after[i]):
+			return fmt.Errorf("token %d: %#v has changed into %#v", i, before[i].Value, after[i].Value)
+		}
 	}
-	return true
+	return nil
 }
 
-// removes whitespaces and new lines from src
-func removeSpace(src string) string {
-	var result []rune
-	for _, r := range src {
-		if string(r) == "\n" || string(r) == " " || string(r) == "\t" || string(r) == "　" {
-			continue
-		}
-		result = append(result, r)
+// isEquivalentToken compares values of literals, idents and placeholders exactly and the others case-insensitively
+func isEquivalentToken(a, b lexer.Token) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case lexer.IDENT, lexer.STRING, lexer.PLACEHOLDER, lexer.FUNCTION:
+		return a.Value == b.Value
 	}
-	return strings.ToLower(string(result))
+	return strings.EqualFold(a.Value, b.Value)
 }
@@ -4,26 +4,54 @@ import (
 	"testing"
 )
 
-func TestCompare(t *testing.T) {
-	test := struct {
-		before string
-		after  string
-		want   bool
+func TestCheckEquivalence(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		wantErr string
 	}{
-		before: "select * from xxx",
-		after:  "select\n  *\nFROM xxx",
-		want:   true,
+		{
+			name:   "keywords are compared case-insensitively",
+			before: "select * from xxx where xxx = 'A B'",
+			after:  "\nSELECT\n  *\nFROM xxx\nWHERE xxx = 'A B'",
+		},
+		{
+			name:    "literals are compared exactly",
+			before:  "select * from xxx where xxx = 'A B'",
+			after:   "SELECT * FROM xxx WHERE xxx = 'ab'",
+			wantErr: `token 7: "'A B'" has changed into "'ab'"`,
+		},
+		{
+			name:    "whitespace between tokens",
+			before:  "select a b from xxx",
+			after:   "SELECT ab FROM xxx",
+			wantErr: `token 1: "a" has changed into "ab"`,
+		},
+		{
+			name:    "type of token",
+			before:  "select xxx (1) from xxx",
+			after:   "SELECT xxx(1) FROM xxx",
+			wantErr: `token 1: type of "xxx" has changed`,
+		},
+		{
+			name:    "removed token",
+			before:  "select xxx from xxx",
+			after:   "SELECT xxx FROM",
+			wantErr: `token 3: "xxx" has changed into "EOF"`,
+		},
 	}
-	if got := compare(test.before, test.after); got != test.want {
-		t.Errorf("want %v#v got %#v", test.want, got)
-	}
-}
-
-func TestRemove(t *testing.T) {
-	got := removeSpace("select xxx from xxx")
-	want := "selectxxxfromxxx"
-	if got != want {
-		t.Errorf("want %#v, got %#v", want, got)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEquivalence(tt.before, tt.after, &Options{})
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Errorf("want %#v, got %#v", tt.wantErr, got)
+			}
+		})
 	}
 }
 
@@ -42,6 +70,95 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatCompact(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{
+			src:  "select xxx ,xxx\nfrom xxx\nwhere xxx in ( $1, $2 ) and xxx = 'a  b'",
+			want: "SELECT xxx, xxx FROM xxx WHERE xxx IN ($1, $2) AND xxx = 'a  b'",
+		},
+		{
+			src:  "select count( * ), sum(xxx)::int, xxx[1] from xxx where exists ( select xxx from xxx )",
+			want: "SELECT COUNT(*), SUM(xxx)::int, xxx[1] FROM xxx WHERE EXISTS (SELECT xxx FROM xxx)",
+		},
+		{
+			src:  "insert into xxx(xxx, xxx) values ($1, $2) on conflict (xxx) do update set xxx = excluded.xxx",
+			want: "INSERT INTO xxx (xxx, xxx) VALUES ($1, $2) ON conflict (xxx) DO UPDATE SET xxx = excluded.xxx",
+		},
+		{
+			src:  "select xxx, case when xxx then xxx else xxx end from xxx",
+			want: "SELECT xxx, CASE WHEN xxx THEN xxx ELSE xxx END FROM xxx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			got, err := Format(tt.src, &Options{Style: Compact, Distance: 4})
+			if err != nil {
+				t.Errorf("should be nil, got %v", err)
+			}
+			if tt.want != got {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatCompactLineComment(t *testing.T) {
+	for _, src := range []string{
+		"select a -- pick a\nfrom t where id = 1",
+		"select a --pick a\nfrom t where id = 1",
+	} {
+		t.Run(src, func(t *testing.T) {
+			got, err := Format(src, &Options{Style: Compact})
+			if err == nil {
+				t.Errorf("should be error, got nil")
+			}
+			if got != src {
+				t.Errorf("want %#v, got %#v", src, got)
+			}
+		})
+	}
+}
+
+func TestFormatMalformed(t *testing.T) {
+	for _, src := range []string{
+		"",
+		"seleCt from)",
+		"select xxx from xxx where (xxx",
+		"select count(",
+		"select xxx from xxx where xxx in ([1)]",
+		"select 'xxx",
+	} {
+		t.Run(src, func(t *testing.T) {
+			got, err := Format(src, &Options{})
+			if err == nil {
+				t.Errorf("should be error, got nil")
+			}
+			if got != src {
+				t.Errorf("want %#v, got %#v", src, got)
+			}
+		})
+	}
+}
+
+func TestFormatIdempotency(t *testing.T) {
+	for _, opt := range []*Options{
+		{Verify: true},
+		{Distance: 4, Verify: true},
+		{Style: Compact, Verify: true},
+	} {
+		for _, tt := range formatTestingData {
+			t.Run(tt.src, func(t *testing.T) {
+				if _, err := Format(tt.src, opt); err != nil {
+					t.Errorf("should be nil, got %v", err)
+				}
+			})
+		}
+	}
+}
+
 var formatTestingData = []struct {
 	src  string
 	want string
@@ -111,11 +228,13 @@ AND xxx = $2`,
 		src: `select
 	                xxx
 	        from xxx
+	        where xxx = $2
 	        and xxx in ($3, $4, $5, $6)`,
 		want: `
 SELECT
   xxx
 FROM xxx
+WHERE xxx = $2
 AND xxx IN ($3, $4, $5, $6)`,
 	},
 	{
@@ -374,11 +493,38 @@ SELECT
   , 8 / 2
   , 1 + 1 * 3
   , 3 + 8 / 7
-  , 1+1*3
-  , 312+8/7
-  , 4%3
-  , 7^5
+  , 1 + 1 * 3
+  , 312 + 8 / 7
+  , 4 % 3
+  , 7 ^ 5
 FROM xxx`,
+	},
+	{
+		src: `select xxx=xxx, xxx||xxx, xxx->>'xxx', xxx@>'{1}', xxx::int, xxx.*, xxx*2, xxx=-1 from xxx where xxx<=-1 and xxx~*'xxx'`,
+		want: `
+SELECT
+  xxx = xxx
+  , xxx || xxx
+  , xxx ->> 'xxx'
+  , xxx @> '{1}'
+  , xxx::int
+  , xxx.*
+  , xxx * 2
+  , xxx = -1
+FROM xxx
+WHERE xxx <= -1
+AND xxx ~* 'xxx'`,
+	},
+	{
+		src: `select xxx from xxx where xxx=$1::int and xxx=:xxx and xxx=@xxx and xxx in (?,?)`,
+		want: `
+SELECT
+  xxx
+FROM xxx
+WHERE xxx = $1::int
+AND xxx = :xxx
+AND xxx = @xxx
+AND xxx IN (?, ?)`,
 	},
 	{
 		src: `select
@@ -439,6 +585,23 @@ FROM xxx`,
 		want: `
 SELECT
   '2015-01-01 00:00:00-09'::timestamptz AT TIME ZONE 'America/Chicago'
+FROM xxx`,
+	},
+	{
+		src: `select b::numeric(10,2), xxx from xxx where b::numeric(10,2) > 1`,
+		want: `
+SELECT
+  b::numeric(10, 2)
+  , xxx
+FROM xxx
+WHERE b::numeric(10, 2) > 1`,
+	},
+	{
+		src: `select xxx :: int, xxx ::  numeric(10,2) from xxx`,
+		want: `
+SELECT
+  xxx::int
+  , xxx::numeric(10, 2)
 FROM xxx`,
 	},
 	{
@@ -845,8 +1008,120 @@ SELECT
 		want: `
 SELECT
   foo
-  , ROW_NUMBER() OVER(range unbounded preceding)
+  , ROW_NUMBER() OVER (RANGE UNBOUNDED PRECEDING)
 FROM baz`,
+	},
+	{
+		src: `select
+	    xxx,
+	    sum(xxx) over (partition by xxx, xxx order by xxx desc rows between unbounded preceding and current row exclude ties) as xxx,
+	    rank() over w
+	  from xxx
+	  having count(xxx) > 1
+	  window w as (partition by xxx order by xxx), ww as (order by xxx)
+	  order by xxx`,
+		want: `
+SELECT
+  xxx
+  , SUM(xxx) OVER (
+    PARTITION BY xxx, xxx
+    ORDER BY xxx DESC
+    ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW EXCLUDE TIES
+  ) AS xxx
+  , rank() OVER w
+FROM xxx
+HAVING COUNT(xxx) > 1
+WINDOW
+  w AS (
+    PARTITION BY xxx
+    ORDER BY xxx
+  )
+  , ww AS (ORDER BY xxx)
+ORDER BY
+  xxx`,
+	},
+	{
+		src: `select
+	    jsonb_build_object('xxx', xxx),
+	    now(),
+	    xxx.fn(xxx, xxx),
+	    coalesce(lower(xxx), '')
+	  from xxx
+	  where lower(xxx) = lower($1)`,
+		want: `
+SELECT
+  jsonb_build_object('xxx', xxx)
+  , now()
+  , xxx.fn(xxx, xxx)
+  , COALESCE(lower(xxx), '')
+FROM xxx
+WHERE lower(xxx) = lower($1)`,
+	},
+	{
+		src: `insert into xxx(xxx, xxx) values ($1, $2)`,
+		want: `
+INSERT INTO xxx (xxx, xxx)
+VALUES ($1, $2)`,
+	},
+	{
+		src: `select xxx from xxx join xxx on xxx = xxx and xxx = xxx where xxx between 1 and 2 and (xxx = 1 or xxx = 2) and xxx in (select xxx from xxx where xxx = 1 and xxx = 2) group by xxx having count(xxx) > 1 or sum(xxx) > 1`,
+		want: `
+SELECT
+  xxx
+FROM xxx
+JOIN xxx
+ON xxx = xxx
+AND xxx = xxx
+WHERE xxx BETWEEN 1 AND 2
+AND (
+  xxx = 1
+  OR xxx = 2
+)
+AND xxx IN (
+  SELECT
+    xxx
+  FROM xxx
+  WHERE xxx = 1
+  AND xxx = 2
+)
+GROUP BY
+  xxx
+HAVING COUNT(xxx) > 1
+OR SUM(xxx) > 1`,
+	},
+	{
+		src: `select xxx from xxx where
+xxx = 1 and (xxx = 2
+or (xxx = 3 and xxx = 4))
+and xxx = coalesce(xxx, 1)`,
+		want: `
+SELECT
+  xxx
+FROM xxx
+WHERE xxx = 1
+AND (
+  xxx = 2
+  OR (
+    xxx = 3
+    AND xxx = 4
+  )
+)
+AND xxx = COALESCE(xxx, 1)`,
+	},
+	{
+		src: `select 'it''s' from xxx`,
+		want: `
+SELECT
+  'it''s'
+FROM xxx`,
+	},
+	{
+		src: `select count(xxx in (1, 2)), xxx from xxx`,
+		want: `
+SELECT
+  COUNT(xxx IN (1, 2))
+  , xxx
+FROM xxx`,
 	},
 	{
 		src: `select xxx from xxx union all select xxx from xxx`,
@@ -865,4 +1140,73 @@ FROM xxx`,
 LOCK table
 IN xxx`,
 	},
+	{
+		src: `create table if not exists xxx (
+	    id bigserial primary key,
+	    name varchar(20) not null default 'xxx',
+	    price numeric(10,2) check (price > 0),
+	    xxx_id integer references xxx (id) on delete cascade,
+	    constraint xxx unique (name, xxx_id)
+	  )`,
+		want: `
+CREATE TABLE IF NOT EXISTS xxx (
+  id BIGSERIAL PRIMARY KEY
+  , name VARCHAR(20) NOT NULL DEFAULT 'xxx'
+  , price NUMERIC(10, 2) CHECK (price > 0)
+  , xxx_id INTEGER REFERENCES xxx (id) ON DELETE CASCADE
+  , CONSTRAINT xxx UNIQUE (name, xxx_id)
+)`,
+	},
+	{
+		src: `alter table xxx add column xxx integer, drop column xxx cascade, alter column xxx set default 0`,
+		want: `
+ALTER TABLE xxx
+  ADD COLUMN xxx INTEGER
+  , DROP COLUMN xxx CASCADE
+  , ALTER COLUMN xxx SET DEFAULT 0`,
+	},
+	{
+		src: `create unique index concurrently xxx on xxx using btree (xxx, xxx) where xxx is null`,
+		want: `
+CREATE UNIQUE INDEX CONCURRENTLY xxx
+ON xxx USING btree (xxx, xxx)
+WHERE xxx IS NULL`,
+	},
+	{
+		src: `create materialized view xxx as select xxx, xxx from xxx where xxx = 1`,
+		want: `
+CREATE MATERIALIZED VIEW xxx AS
+SELECT
+  xxx
+  , xxx
+FROM xxx
+WHERE xxx = 1`,
+	},
+	{
+		src: `create view xxx as select xxx from (select xxx from xxx where xxx = 1) as xxx`,
+		want: `
+CREATE VIEW xxx AS
+SELECT
+  xxx
+FROM (
+  SELECT
+    xxx
+  FROM xxx
+  WHERE xxx = 1
+) AS xxx`,
+	},
+	{
+		src: `drop table if exists xxx, xxx cascade`,
+		want: `
+DROP TABLE IF EXISTS xxx, xxx CASCADE`,
+	},
+	{
+		src: `select drop, create from xxx where alter = 1`,
+		want: `
+SELECT
+  drop
+  , create
+FROM xxx
+WHERE alter = 1`,
+	},
 }
@@ -0,0 +1,38 @@
+package sqlfmt
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func addFormatTestingData(f *testing.F) {
+	for _, tt := range formatTestingData {
+		f.Add(tt.src)
+	}
+}
+
+// FuzzFormat checks Format does not panic, and the formatted statement is equivalent to src
+// and formatting it again does not change it
+func FuzzFormat(f *testing.F) {
+	addFormatTestingData(f)
+	f.Fuzz(func(t *testing.T, src string) {
+		res, err := Format(src, &Options{})
+		if _, ok := errors.Cause(err).(*panicError); ok {
+			t.Fatalf("Format of %#v panicked: %v", src, err)
+		}
+		if err != nil {
+			return
+		}
+		if err := checkEquivalence(src, res, &Options{}); err != nil {
+			t.Fatalf("%#v is formatted into %#v: %v", src, res, err)
+		}
+		again, err := Format(res, &Options{})
+		if err != nil {
+			t.Fatalf("Format of %#v failed: %v", res, err)
+		}
+		if again != res {
+			t.Fatalf("%#v is formatted into %#v, then %#v", src, res, again)
+		}
+	})
+}
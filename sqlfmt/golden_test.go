@@ -0,0 +1,117 @@
+package sqlfmt
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// TestGoldenSQL formats testdata/xxx.sql and compares the result with testdata/xxx.golden.sql
+func TestGoldenSQL(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, ".golden.sql") {
+			continue
+		}
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			src, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Format(string(src), &Options{})
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+			// golden file ends with new line
+			checkGolden(t, strings.TrimSuffix(file, ".sql")+".golden.sql", []byte(got+"\n"))
+		})
+	}
+}
+
+// TestGoldenGo processes testdata/xxx.go and compares the result with testdata/xxx.golden.go
+// options can be set by flags in the first line of testdata/xxx.go such as "//sqlfmt -distance=2"
+func TestGoldenGo(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, ".golden.go") {
+			continue
+		}
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			src, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options := goldenOptions(t, src)
+			got, err := Process(file, src, options)
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+			checkGolden(t, strings.TrimSuffix(file, ".go")+".golden.go", got)
+
+			// processing the result again does not change it
+			again, err := Process(file, got, options)
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+			if !bytes.Equal(got, again) {
+				t.Errorf("processing the result again changed it: \n%s", again)
+			}
+		})
+	}
+}
+
+// goldenOptions parses flags in the first line of src such as "//sqlfmt -fold-concat"
+func goldenOptions(t *testing.T, src []byte) *Options {
+	t.Helper()
+
+	options := &Options{}
+	line := strings.SplitN(string(src), "\n", 2)[0]
+	if !strings.HasPrefix(line, "//sqlfmt") {
+		return options
+	}
+
+	fs := flag.NewFlagSet("sqlfmt", flag.ContinueOnError)
+	fs.IntVar(&options.Distance, "distance", 0, "")
+	fs.BoolVar(&options.FoldConcat, "fold-concat", false, "")
+	fs.BoolVar(&options.ConvertStrings, "convert-strings", false, "")
+	indent := fs.String("indent", "", "")
+	compact := fs.Bool("compact", false, "")
+	if err := fs.Parse(strings.Fields(line)[1:]); err != nil {
+		t.Fatal(err)
+	}
+	options.AutoIndent = *indent == "auto"
+	if *compact {
+		options.Style = Compact
+	}
+	return options
+}
+
+// checkGolden compares got with the content of golden file, or overwrites golden file with got if -update is set
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run go test with -update to create golden file)", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("\nwant %#v, \ngot %#v", string(want), string(got))
+	}
+}
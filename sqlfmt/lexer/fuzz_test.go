@@ -0,0 +1,42 @@
+package lexer
+
+import (
+	"testing"
+)
+
+func addTokenizerTestingData(f *testing.F) {
+	for _, tt := range ddlTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range windowSpecTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range functionCallTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range operatorTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range doubledQuoteTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range unterminatedQuoteTestingData {
+		f.Add(tt.src)
+	}
+	for _, tt := range lineCommentTestingData {
+		f.Add(tt.src)
+	}
+}
+
+func FuzzTokenize(f *testing.F) {
+	addTokenizerTestingData(f)
+	f.Fuzz(func(t *testing.T, src string) {
+		tokens, err := NewTokenizer(src).GetTokens()
+		if err != nil {
+			return
+		}
+		if len(tokens) == 0 || tokens[len(tokens)-1].Type != EOF {
+			t.Errorf("tokens should end with EOF, got %#v", tokens)
+		}
+	})
+}
@@ -18,8 +18,10 @@ const (
 	STARTBRACE
 	ENDBRACE
 	TYPE
-	IDENT  // field or table name
-	STRING // values surrounded with single quotes
+	IDENT       // field or table name
+	STRING      // values surrounded with single quotes
+	OPERATOR    // operators such as =, <>, ||, ->>, @>, ~*
+	PLACEHOLDER // bind parameters such as $1, ?, :name, @name
 	SELECT
 	FROM
 	WHERE
@@ -87,6 +89,42 @@ const (
 	AT
 	LOCK
 	WITH
+	CREATE
+	ALTER
+	DROP
+	TABLE
+	INDEX
+	VIEW
+	UNIQUE
+	CONCURRENTLY
+	MATERIALIZED
+	ADD
+	COLUMN
+	CONSTRAINT
+	PRIMARY
+	FOREIGN
+	KEY
+	REFERENCES
+	DEFAULT
+	CHECK
+	IF
+	CASCADE
+	RESTRICT
+	RENAME
+	TO
+	WINDOW
+	PARTITION
+	RANGE
+	GROUPS
+	UNBOUNDED
+	PRECEDING
+	FOLLOWING
+	CURRENT
+	ROW
+	EXCLUDE
+	TIES
+	OTHERS
+	NO
 
 	QUOTEAREA
 	SURROUNDING
@@ -111,13 +149,14 @@ func (t Token) IncrementIndentLevel(lev int) {}
 var (
 	EndOfSelect      = []TokenType{FROM, UNION, EOF}
 	EndOfCase        = []TokenType{END}
-	EndOfFrom        = []TokenType{WHERE, INNER, OUTER, LEFT, RIGHT, JOIN, NATURAL, CROSS, ORDER, GROUP, UNION, OFFSET, LIMIT, FETCH, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
-	EndOfJoin        = []TokenType{WHERE, ORDER, GROUP, LIMIT, OFFSET, FETCH, ANDGROUP, ORGROUP, LEFT, RIGHT, INNER, OUTER, NATURAL, CROSS, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
-	EndOfWhere       = []TokenType{GROUP, ORDER, LIMIT, OFFSET, FETCH, ANDGROUP, OR, UNION, EXCEPT, INTERSECT, RETURNING, EOF, ENDPARENTHESIS}
-	EndOfAndGroup    = []TokenType{GROUP, ORDER, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT, ANDGROUP, ORGROUP, EOF, ENDPARENTHESIS}
-	EndOfOrGroup     = []TokenType{GROUP, ORDER, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT, ANDGROUP, ORGROUP, EOF, ENDPARENTHESIS}
-	EndOfGroupBy     = []TokenType{ORDER, LIMIT, FETCH, OFFSET, UNION, EXCEPT, INTERSECT, HAVING, EOF, ENDPARENTHESIS}
-	EndOfHaving      = []TokenType{LIMIT, OFFSET, FETCH, ORDER, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
+	EndOfFrom        = []TokenType{WHERE, INNER, OUTER, LEFT, RIGHT, JOIN, NATURAL, CROSS, ORDER, GROUP, WINDOW, UNION, OFFSET, LIMIT, FETCH, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
+	EndOfJoin        = []TokenType{WHERE, ORDER, GROUP, WINDOW, LIMIT, OFFSET, FETCH, ANDGROUP, ORGROUP, LEFT, RIGHT, INNER, OUTER, NATURAL, CROSS, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
+	EndOfWhere       = []TokenType{GROUP, ORDER, WINDOW, LIMIT, OFFSET, FETCH, ANDGROUP, ORGROUP, UNION, EXCEPT, INTERSECT, RETURNING, EOF, ENDPARENTHESIS}
+	EndOfAndGroup    = []TokenType{GROUP, ORDER, WINDOW, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT, ANDGROUP, ORGROUP, EOF, ENDPARENTHESIS}
+	EndOfOrGroup     = []TokenType{GROUP, ORDER, WINDOW, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT, ANDGROUP, ORGROUP, EOF, ENDPARENTHESIS}
+	EndOfGroupBy     = []TokenType{ORDER, WINDOW, LIMIT, FETCH, OFFSET, UNION, EXCEPT, INTERSECT, HAVING, EOF, ENDPARENTHESIS}
+	EndOfHaving      = []TokenType{WINDOW, LIMIT, OFFSET, FETCH, ORDER, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
+	EndOfWindow      = []TokenType{ORDER, LIMIT, FETCH, OFFSET, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
 	EndOfOrderBy     = []TokenType{LIMIT, FETCH, OFFSET, UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
 	EndOfLimitClause = []TokenType{UNION, EXCEPT, INTERSECT, EOF, ENDPARENTHESIS}
 	EndOfParenthesis = []TokenType{ENDPARENTHESIS}
@@ -129,17 +168,29 @@ var (
 	EndOfInsert      = []TokenType{VALUES, EOF}
 	EndOfValues      = []TokenType{UPDATE, RETURNING, EOF}
 	EndOfFunction    = []TokenType{ENDPARENTHESIS}
+	EndOfOver        = []TokenType{ENDPARENTHESIS}
 	EndOfTypeCast    = []TokenType{ENDPARENTHESIS}
 	EndOfLock        = []TokenType{EOF}
 	EndOfWith        = []TokenType{EOF}
+	EndOfCreate      = []TokenType{SELECT, WHERE, EOF}
+	EndOfAlter       = []TokenType{EOF}
+	EndOfDrop        = []TokenType{EOF}
 )
 
 // token types that contain the keyword to make subGroup
 var (
-	TokenTypesOfGroupMaker = []TokenType{SELECT, CASE, FROM, WHERE, ORDER, GROUP, LIMIT, ANDGROUP, ORGROUP, HAVING, UNION, EXCEPT, INTERSECT, FUNCTION, STARTPARENTHESIS, TYPE}
+	TokenTypesOfGroupMaker = []TokenType{SELECT, CASE, FROM, WHERE, ORDER, GROUP, LIMIT, ANDGROUP, ORGROUP, HAVING, WINDOW, UNION, EXCEPT, INTERSECT, FUNCTION, OVER, STARTPARENTHESIS, TYPE}
 	TokenTypesOfJoinMaker  = []TokenType{JOIN, INNER, OUTER, LEFT, RIGHT, NATURAL, CROSS}
 	TokenTypeOfTieClause   = []TokenType{UNION, INTERSECT, EXCEPT}
 	TokenTypeOfLimitClause = []TokenType{LIMIT, FETCH, OFFSET}
+	TokenTypeOfAlterAction = []TokenType{ADD, DROP, ALTER, RENAME}
+	TokenTypeOfWindowSpec  = []TokenType{PARTITION, ORDER, ROWS, RANGE, GROUPS}
+)
+
+// token types of clauses that AND, OR make condition groups in and the other clauses
+var (
+	TokenTypesOfConditionClause = []TokenType{WHERE, HAVING, ON}
+	TokenTypesOfOtherClause     = []TokenType{SELECT, FROM, JOIN, INNER, OUTER, LEFT, RIGHT, NATURAL, CROSS, USING, GROUP, ORDER, WINDOW, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT, RETURNING, SET, VALUES}
 )
 
 // IsJoinStart determines if ttype is included in TokenTypesOfJoinMaker
@@ -172,6 +223,46 @@ func (t Token) IsLimitClauseStart() bool {
 	return false
 }
 
+// IsAlterActionStart determines if ttype is included in TokenTypeOfAlterAction
+func (t Token) IsAlterActionStart() bool {
+	for _, v := range TokenTypeOfAlterAction {
+		if t.Type == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWindowSpecClauseStart determines if ttype is included in TokenTypeOfWindowSpec
+func (t Token) IsWindowSpecClauseStart() bool {
+	for _, v := range TokenTypeOfWindowSpec {
+		if t.Type == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConditionClauseStart determines if ttype is included in TokenTypesOfConditionClause
+func (t Token) IsConditionClauseStart() bool {
+	for _, v := range TokenTypesOfConditionClause {
+		if t.Type == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOtherClauseStart determines if ttype is included in TokenTypesOfOtherClause
+func (t Token) IsOtherClauseStart() bool {
+	for _, v := range TokenTypesOfOtherClause {
+		if t.Type == v {
+			return true
+		}
+	}
+	return false
+}
+
 // IsNeedNewLineBefore returns true if token needs new line before written in buffer
 func (t Token) IsNeedNewLineBefore() bool {
 	var ttypes = []TokenType{SELECT, UPDATE, INSERT, DELETE, ANDGROUP, FROM, GROUP, ORGROUP, ORDER, HAVING, LIMIT, OFFSET, FETCH, RETURNING, SET, UNION, INTERSECT, EXCEPT, VALUES, WHERE, ON, USING, UNION, EXCEPT, INTERSECT}
@@ -10,9 +10,18 @@ import (
 
 // Tokenizer tokenizes SQL statements
 type Tokenizer struct {
-	r      *bufio.Reader
+	r      *reader
 	w      *bytes.Buffer // w  writes token value. It resets its value when the end of token appears
 	result []Token
+	// offsets holds byte offsets in src of tokens in result
+	offsets []int
+	ddl     bool // ddl is true while tokenizing DDL statements, in which ddlKeywordMap is also looked up
+	window  bool // window is true while tokenizing WINDOW clause
+	// windowDepth is the depth of parenthesis in window specification such as OVER (...)
+	// windowKeywordMap is also looked up while it is positive
+	windowDepth int
+	// PrintfVerbs makes printf verbs such as %s, %d in templates of fmt.Sprintf scanned as placeholders
+	PrintfVerbs bool
 }
 
 // rune that can't be contained in SQL statement
@@ -29,51 +38,57 @@ const (
 	StartBrace       = "{"
 	EndBrace         = "}"
 	SingleQuote      = "'"
+	DoubleQuote      = "\""
 	NewLine          = "\n"
+	TypeCast         = "::"
 )
 
+// maxPrintfVerbLen is the max length of printf verb such as %-10.2f, %[1]s which can be scanned
+const maxPrintfVerbLen = 16
+
 // NewTokenizer creates Tokenizer
 func NewTokenizer(src string) *Tokenizer {
 	return &Tokenizer{
-		r: bufio.NewReader(strings.NewReader(src)),
+		r: &reader{Reader: bufio.NewReader(strings.NewReader(src))},
 		w: &bytes.Buffer{},
 	}
 }
 
 // GetTokens returns tokens for parsing
 func (t *Tokenizer) GetTokens() ([]Token, error) {
-	var result []Token
+	result, _, err := t.GetTokensWithOffsets()
+	return result, err
+}
+
+// GetTokensWithOffsets returns tokens for parsing and their byte offsets in src
+func (t *Tokenizer) GetTokensWithOffsets() ([]Token, []int, error) {
+	var (
+		result  []Token
+		offsets []int
+	)
 
 	tokens, err := t.Tokenize()
 	if err != nil {
-		return nil, errors.Wrap(err, "Tokenize failed")
+		return nil, nil, errors.Wrap(err, "Tokenize failed")
 	}
 	// replace all tokens without whitespaces and new lines
-	// if "AND" or "OR" appears after new line, token value will be ANDGROUP, ORGROUP
 	for i, tok := range tokens {
-		if tok.Type == AND && tokens[i-1].Type == NEWLINE {
-			andGroupToken := Token{Type: ANDGROUP, Value: tok.Value}
-			result = append(result, andGroupToken)
-			continue
-		}
-		if tok.Type == OR && tokens[i-1].Type == NEWLINE {
-			orGroupToken := Token{Type: ORGROUP, Value: tok.Value}
-			result = append(result, orGroupToken)
-			continue
-		}
 		if tok.Type == WS || tok.Type == NEWLINE {
 			continue
 		}
 		result = append(result, tok)
+		offsets = append(offsets, t.offsets[i])
 	}
-	return result, nil
+	return result, offsets, nil
 }
 
 // Tokenize analyses every rune in SQL statement
 // every token is identified when whitespace appears
 func (t *Tokenizer) Tokenize() ([]Token, error) {
 	for {
+		start := t.r.offset
 		isEOF, err := t.scan()
+		t.appendOffsets(start)
 
 		if isEOF {
 			break
@@ -85,6 +100,47 @@ func (t *Tokenizer) Tokenize() ([]Token, error) {
 	return t.result, nil
 }
 
+// appendOffsets appends offsets of tokens appended to result by a scan which started at start
+// tokens appended by the same scan such as xxx and ::int of xxx::int are regarded as adjoining
+func (t *Tokenizer) appendOffsets(start int) {
+	for i := len(t.offsets); i < len(t.result); i++ {
+		if i > 0 && len(t.offsets) > 0 && t.offsets[i-1] >= start {
+			start = t.offsets[i-1] + len(t.result[i-1].Value)
+		}
+		t.offsets = append(t.offsets, start)
+	}
+}
+
+// reader is bufio.Reader counting the byte offset of the next rune
+type reader struct {
+	*bufio.Reader
+	offset   int
+	lastSize int
+}
+
+func (r *reader) ReadRune() (rune, int, error) {
+	ch, size, err := r.Reader.ReadRune()
+	if err == nil {
+		r.offset += size
+		r.lastSize = size
+	}
+	return ch, size, err
+}
+
+func (r *reader) UnreadRune() error {
+	if err := r.Reader.UnreadRune(); err != nil {
+		return err
+	}
+	r.offset -= r.lastSize
+	return nil
+}
+
+func (r *reader) Discard(n int) (int, error) {
+	discarded, err := r.Reader.Discard(n)
+	r.offset += discarded
+	return discarded, err
+}
+
 // unread undoes t.r.readRune method to get last character
 func (t *Tokenizer) unread() { t.r.UnreadRune() }
 
@@ -124,9 +180,40 @@ func isEndBrace(ch rune) bool {
 	return ch == '}'
 }
 
+func isDoubleQuote(ch rune) bool {
+	return ch == '"'
+}
+
+// isDelimiter returns true if ch is a punctuation which can not be a part of ident
+func isDelimiter(ch rune) bool {
+	return strings.ContainsRune(",;()[]{}", ch)
+}
+
+// isOperatorChar returns true if ch can be a part of operator in PostgreSQL
+func isOperatorChar(ch rune) bool {
+	return strings.ContainsRune("+-*/<>=~!@#%^&|`", ch)
+}
+
+// isUnaryOperator returns true if op can be a prefix operator such as -42 or @foo
+func isUnaryOperator(op string) bool {
+	return op == "-" || op == "+" || op == "@" || op == "~" || op == "!!" || op == "|/" || op == "||/"
+}
+
+// isOperand returns true if the token can be a left side of binary operator
+func isOperand(tok Token) bool {
+	switch tok.Type {
+	case IDENT, STRING, PLACEHOLDER, ENDPARENTHESIS, ENDBRACKET, ENDBRACE, NULL, END:
+		return true
+	}
+	return false
+}
+
 // scan scans each character and appends to result until "eof" appears
 // when it finishes scanning all characters, it returns true
 func (t *Tokenizer) scan() (bool, error) {
+	// lookahead must be peeked before reading rune so that scanning functions can unread the rune
+	lookahead, _ := t.r.Peek(len(TypeCast))
+	verbLen := t.peekPrintfVerb()
 	ch, _, err := t.r.ReadRune()
 	if err != nil {
 		if err.Error() == "EOF" {
@@ -146,6 +233,22 @@ func (t *Tokenizer) scan() (bool, error) {
 			return false, err
 		}
 		return false, nil
+	case string(lookahead) == TypeCast:
+		if err := t.scanIdent(); err != nil {
+			return false, err
+		}
+		return false, nil
+	case verbLen > 0:
+		if err := t.scanPrintfVerb(verbLen); err != nil {
+			return false, err
+		}
+		return false, nil
+	// "--" starts comment, which is not supported, so it is scanned as an ident
+	case isOperatorChar(ch) && string(lookahead) != "--":
+		if err := t.scanOperator(); err != nil {
+			return false, err
+		}
+		return false, nil
 	// extract string
 	case isSingleQuote(ch):
 		if err := t.scanString(); err != nil {
@@ -157,10 +260,16 @@ func (t *Tokenizer) scan() (bool, error) {
 		t.result = append(t.result, token)
 		return false, nil
 	case isStartParenthesis(ch):
+		if t.isWindowSpecStart() || t.windowDepth > 0 {
+			t.windowDepth++
+		}
 		token := Token{Type: STARTPARENTHESIS, Value: StartParenthesis}
 		t.result = append(t.result, token)
 		return false, nil
 	case isEndParenthesis(ch):
+		if t.windowDepth > 0 {
+			t.windowDepth--
+		}
 		token := Token{Type: ENDPARENTHESIS, Value: EndParenthesis}
 		t.result = append(t.result, token)
 		return false, nil
@@ -220,6 +329,7 @@ func (t *Tokenizer) scanWhiteSpace() error {
 }
 
 // scan string token including single quotes
+// two consecutive single quotes in string are scanned as an escaped single quote
 func (t *Tokenizer) scanString() error {
 	var counter int
 	t.unread()
@@ -228,16 +338,17 @@ func (t *Tokenizer) scanString() error {
 		ch, _, err := t.r.ReadRune()
 		if err != nil {
 			if err.Error() == "EOF" {
-				break
-			} else {
-				return err
+				return errors.Errorf("unterminated string %s", t.w.String())
 			}
+			return err
 		}
+		t.w.WriteRune(ch)
 		// ignore the first single quote
 		if counter != 0 && isSingleQuote(ch) {
-			t.w.WriteRune(ch)
-			break
-		} else {
+			if !t.isFollowedBy(isSingleQuote) {
+				break
+			}
+			t.r.ReadRune()
 			t.w.WriteRune(ch)
 		}
 		counter++
@@ -248,12 +359,78 @@ func (t *Tokenizer) scanString() error {
 	return nil
 }
 
+// scanOperator scans operator such as =, <>, ||, ->>, @>
+// following the rule of PostgreSQL, "--" and "/*" can not be contained in operator,
+// and operator can not end in "+" or "-" unless it contains ~ ! @ # % ^ & | `
+// prefix operator such as -42 is scanned as a part of the following ident, and "*" that is not operator such as SELECT * is scanned as an ident
+func (t *Tokenizer) scanOperator() error {
+	t.unread()
+
+	var (
+		op   string
+		next rune
+	)
+	for n := 1; ; n++ {
+		b, err := t.r.Peek(n)
+		if err != nil {
+			break
+		}
+		if !isOperatorChar(rune(b[n-1])) {
+			next = rune(b[n-1])
+			break
+		}
+		op = string(b)
+	}
+	// placeholder such as =@name is not a part of operator
+	if len(op) > 1 && strings.HasSuffix(op, "@") && isNameStart(next) {
+		op = op[:len(op)-1]
+	}
+	if idx := strings.Index(op[1:], "--"); idx >= 0 {
+		op = op[:idx+1]
+	}
+	if idx := strings.Index(op[1:], "/*"); idx >= 0 {
+		op = op[:idx+1]
+	}
+	for len(op) > 1 && strings.ContainsAny(op[len(op)-1:], "+-") && !strings.ContainsAny(op, "~!@#%^&|`") {
+		op = op[:len(op)-1]
+	}
+	if _, err := t.r.Discard(len(op)); err != nil {
+		return errors.Wrap(err, "discard operator failed")
+	}
+
+	isPrefix := !isOperand(t.lastToken())
+	switch {
+	case isPrefix && isUnaryOperator(op):
+		next, _, err := t.r.ReadRune()
+		if err != nil {
+			break
+		}
+		if !isWhiteSpace(next) && !isOperatorChar(next) && !isDelimiter(next) && !isSingleQuote(next) {
+			t.w.WriteString(op)
+			return t.scanIdent()
+		}
+		t.unread()
+	case isPrefix && op == "*":
+		t.result = append(t.result, Token{Type: IDENT, Value: op})
+		return nil
+	}
+	t.result = append(t.result, Token{Type: OPERATOR, Value: op})
+	return nil
+}
+
 // append all ch to result until ch is a white space
 // if ident is keyword, Type will be the keyword and value will be the uppercase keyword
 func (t *Tokenizer) scanIdent() error {
 	t.unread()
 
 	for {
+		// printf verb in ident such as xxx_%s is scanned as a part of ident
+		if verbLen := t.peekPrintfVerb(); verbLen > 0 {
+			if err := t.writePrintfVerb(verbLen); err != nil {
+				return err
+			}
+			continue
+		}
 		ch, _, err := t.r.ReadRune()
 		if err != nil {
 			if err.Error() == "EOF" {
@@ -262,6 +439,20 @@ func (t *Tokenizer) scanIdent() error {
 				return err
 			}
 		}
+		if isDoubleQuote(ch) {
+			if err := t.scanQuotedIdent(); err != nil {
+				return err
+			}
+			continue
+		}
+		// whitespaces after type cast such as xxx :: int are skipped so that it is scanned as ::int
+		if t.w.String() == TypeCast && isWhiteSpace(ch) {
+			continue
+		}
+		if t.isIdentContinued(ch) {
+			t.w.WriteRune(ch)
+			continue
+		}
 		if isWhiteSpace(ch) {
 			t.unread()
 			break
@@ -289,6 +480,13 @@ func (t *Tokenizer) scanIdent() error {
 		} else if isEndBrace(ch) {
 			t.unread()
 			break
+		} else if isOperatorChar(ch) {
+			t.unread()
+			break
+		} else if next, err := t.r.Peek(1); ch == ':' && err == nil && string(next) == ":" {
+			// type cast such as xxx::int is scanned as another ident
+			t.append(t.w.String())
+			t.w.WriteRune(ch)
 		} else {
 			t.w.WriteRune(ch)
 		}
@@ -297,10 +495,135 @@ func (t *Tokenizer) scanIdent() error {
 	return nil
 }
 
+// peekPrintfVerb returns the length of printf verb starting from the next rune
+// it returns 0 if PrintfVerbs is false or the next rune does not start printf verb
+func (t *Tokenizer) peekPrintfVerb() int {
+	if !t.PrintfVerbs {
+		return 0
+	}
+	b, _ := t.r.Peek(maxPrintfVerbLen)
+	return printfVerbLen(b)
+}
+
+// printfVerbLen returns the length of printf verb such as %s, %d, %[1]v, %-5.2f at the beginning of b
+// "%%" is not a verb but a percent sign, which is scanned as an operator
+func printfVerbLen(b []byte) int {
+	if len(b) < 2 || b[0] != '%' {
+		return 0
+	}
+	for i := 1; i < len(b); i++ {
+		switch ch := b[i]; {
+		case ch == ' ' && i == 1, strings.IndexByte("+-#0123456789.*[]", ch) >= 0:
+			continue
+		case ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+			return i + 1
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// writePrintfVerb writes printf verb to w
+func (t *Tokenizer) writePrintfVerb(verbLen int) error {
+	b, err := t.r.Peek(verbLen)
+	if err != nil {
+		return errors.Wrap(err, "peek printf verb failed")
+	}
+	t.w.Write(b)
+	if _, err := t.r.Discard(verbLen); err != nil {
+		return errors.Wrap(err, "discard printf verb failed")
+	}
+	return nil
+}
+
+// scanPrintfVerb scans printf verb as placeholder
+// printf verb followed by ident such as %s_xxx is scanned as an ident
+func (t *Tokenizer) scanPrintfVerb(verbLen int) error {
+	t.unread()
+	if err := t.writePrintfVerb(verbLen); err != nil {
+		return err
+	}
+	if _, _, err := t.r.ReadRune(); err != nil {
+		t.append(t.w.String())
+		return nil
+	}
+	return t.scanIdent()
+}
+
+// isIdentContinued returns true if ch has to be a part of ident even if ch is an operator character
+// such as the first character of ident, xxx.* , 1.5e-3, "::" of type cast and comments
+func (t *Tokenizer) isIdentContinued(ch rune) bool {
+	v := t.w.String()
+	switch {
+	case t.w.Len() == 0:
+		return true
+	case v == ":" && ch == ':':
+		return true
+	case v == "-" && ch == '-':
+		return true
+	case strings.HasPrefix(v, "--"):
+		return !isWhiteSpace(ch)
+	case strings.HasSuffix(v, ".") && ch == '*':
+		return true
+	case (ch == '+' || ch == '-') && isExponent(v):
+		return true
+	}
+	return false
+}
+
+// isExponent returns true if v is a number followed by exponent marker such as 1.5e
+func isExponent(v string) bool {
+	if !strings.HasSuffix(v, "e") && !strings.HasSuffix(v, "E") {
+		return false
+	}
+	for _, r := range v[:len(v)-1] {
+		if !(r >= '0' && r <= '9') && r != '.' {
+			return false
+		}
+	}
+	return len(v) > 1
+}
+
+// scanQuotedIdent writes ident surrounded with double quotes such as "xxx-xxx" as a part of ident
+func (t *Tokenizer) scanQuotedIdent() error {
+	t.w.WriteString(DoubleQuote)
+	for {
+		ch, _, err := t.r.ReadRune()
+		if err != nil {
+			if err.Error() == "EOF" {
+				return errors.Errorf("unterminated quoted identifier %s", t.w.String())
+			}
+			return err
+		}
+		t.w.WriteRune(ch)
+		if isDoubleQuote(ch) {
+			return nil
+		}
+	}
+}
+
+// isFollowedBy returns true if the next rune satisfies f without consuming it
+func (t *Tokenizer) isFollowedBy(f func(rune) bool) bool {
+	ch, _, err := t.r.ReadRune()
+	if err != nil {
+		return false
+	}
+	t.unread()
+	return f(ch)
+}
+
 func (t *Tokenizer) append(v string) {
 	upperValue := strings.ToUpper(v)
 
-	if ttype, ok := t.isSQLKeyWord(upperValue); ok {
+	if isPlaceholder(v) || t.isPrintfVerb(v) {
+		t.result = append(t.result, Token{
+			Type:  PLACEHOLDER,
+			Value: v,
+		})
+	} else if ttype, ok := t.isSQLKeyWord(upperValue); ok {
+		t.switchDDLMode(ttype)
+		t.switchWindowMode(ttype)
 		t.result = append(t.result, Token{
 			Type:  ttype,
 			Value: upperValue,
@@ -314,9 +637,125 @@ func (t *Tokenizer) append(v string) {
 	t.w.Reset()
 }
 
+// isPlaceholder returns true if v is bind parameter
+// such as $1 (PostgreSQL), ? (MySQL), :name (sqlx) and @name (SQL Server)
+func isPlaceholder(v string) bool {
+	switch {
+	case strings.HasPrefix(v, "$") || strings.HasPrefix(v, "?"):
+		return isDigits(v[1:]) && (v[0] == '?' || len(v) > 1)
+	case strings.HasPrefix(v, ":") || strings.HasPrefix(v, "@"):
+		return len(v) > 1 && isNameStart(rune(v[1]))
+	}
+	return false
+}
+
+// isPrintfVerb returns true if v is printf verb such as %s, %d while PrintfVerbs is true
+func (t *Tokenizer) isPrintfVerb(v string) bool {
+	return t.PrintfVerbs && printfVerbLen([]byte(v)) == len(v)
+}
+
+func isDigits(v string) bool {
+	for _, r := range v {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameStart(ch rune) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// switchDDLMode turns on DDL mode when the statement starts with CREATE, ALTER or DROP
+// DDL mode is turned off when SELECT appears, such as CREATE VIEW xxx AS SELECT ...
+func (t *Tokenizer) switchDDLMode(ttype TokenType) {
+	switch {
+	case t.isDDLStart(ttype):
+		t.ddl = true
+	case ttype == SELECT:
+		t.ddl = false
+	}
+}
+
+// isDDLStart returns true if ttype is CREATE, ALTER or DROP which starts the statement
+func (t *Tokenizer) isDDLStart(ttype TokenType) bool {
+	switch ttype {
+	case CREATE, ALTER, DROP:
+		return t.isStartOfStatement()
+	}
+	return false
+}
+
+// switchWindowMode turns on window mode when WINDOW clause starts
+// window mode is turned off when the next clause of WINDOW clause appears
+func (t *Tokenizer) switchWindowMode(ttype TokenType) {
+	switch ttype {
+	case WINDOW:
+		t.window = true
+	case ORDER, LIMIT, OFFSET, FETCH, UNION, EXCEPT, INTERSECT:
+		if t.windowDepth == 0 {
+			t.window = false
+		}
+	}
+}
+
+// isWindowSpecStart returns true if "(" to be tokenized starts window specification
+// such as OVER (...) or WINDOW xxx AS (...)
+func (t *Tokenizer) isWindowSpecStart() bool {
+	switch t.lastToken().Type {
+	case OVER:
+		return true
+	case AS:
+		return t.window
+	}
+	return false
+}
+
+// isFunctionCall returns true if the ident to be appended is immediately followed by "("
+// idents followed by column list such as INSERT INTO xxx(xxx) or REFERENCES xxx(xxx) are not function calls
+func (t *Tokenizer) isFunctionCall() bool {
+	r, _, err := t.r.ReadRune()
+	if err != nil {
+		return false
+	}
+	t.unread()
+	if string(r) != StartParenthesis {
+		return false
+	}
+
+	switch t.lastToken().Type {
+	case INTO, TABLE, VIEW, REFERENCES:
+		return false
+	case ON:
+		// CREATE INDEX ON xxx(xxx)
+		return !t.ddl
+	}
+	return true
+}
+
+// lastToken returns the last token except white spaces and new lines
+func (t *Tokenizer) lastToken() Token {
+	for i := len(t.result) - 1; i >= 0; i-- {
+		if tok := t.result[i]; tok.Type != WS && tok.Type != NEWLINE {
+			return tok
+		}
+	}
+	return Token{}
+}
+
+// isStartOfStatement returns true if no token except white spaces and new lines has been tokenized
+func (t *Tokenizer) isStartOfStatement() bool {
+	return t.lastToken() == Token{}
+}
+
 func (t *Tokenizer) isSQLKeyWord(v string) (TokenType, bool) {
 	if ttype, ok := sqlKeywordMap[v]; ok {
 		return ttype, ok
+	} else if ttype, ok := ddlKeywordMap[v]; ok && (t.ddl || t.isDDLStart(ttype)) {
+		return ttype, ok
+	} else if ttype, ok := windowKeywordMap[v]; ok && t.windowDepth > 0 {
+		return ttype, ok
 	} else if ttype, ok := typeWithParenMap[v]; ok {
 		if r, _, err := t.r.ReadRune(); err == nil && string(r) == StartParenthesis {
 			t.unread()
@@ -324,6 +763,8 @@ func (t *Tokenizer) isSQLKeyWord(v string) (TokenType, bool) {
 		}
 		t.unread()
 		return IDENT, ok
+	} else if t.isFunctionCall() {
+		return FUNCTION, false
 	}
 	return IDENT, false
 }
@@ -392,6 +833,52 @@ var sqlKeywordMap = map[string]TokenType{
 	"AT":          AT,
 	"LOCK":        LOCK,
 	"WITH":        WITH,
+	"OVER":        OVER,
+	"WINDOW":      WINDOW,
+}
+
+// keywords only in DDL statements
+// they are commonly used as column names such as "key" or "index" in the other statements
+var ddlKeywordMap = map[string]TokenType{
+	"CREATE":       CREATE,
+	"ALTER":        ALTER,
+	"DROP":         DROP,
+	"TABLE":        TABLE,
+	"INDEX":        INDEX,
+	"VIEW":         VIEW,
+	"UNIQUE":       UNIQUE,
+	"CONCURRENTLY": CONCURRENTLY,
+	"MATERIALIZED": MATERIALIZED,
+	"ADD":          ADD,
+	"COLUMN":       COLUMN,
+	"CONSTRAINT":   CONSTRAINT,
+	"PRIMARY":      PRIMARY,
+	"FOREIGN":      FOREIGN,
+	"KEY":          KEY,
+	"REFERENCES":   REFERENCES,
+	"DEFAULT":      DEFAULT,
+	"CHECK":        CHECK,
+	"IF":           IF,
+	"CASCADE":      CASCADE,
+	"RESTRICT":     RESTRICT,
+	"RENAME":       RENAME,
+	"TO":           TO,
+}
+
+// keywords only in window specification such as OVER (PARTITION BY xxx ORDER BY xxx ROWS BETWEEN xxx AND xxx)
+var windowKeywordMap = map[string]TokenType{
+	"PARTITION": PARTITION,
+	"RANGE":     RANGE,
+	"GROUPS":    GROUPS,
+	"UNBOUNDED": UNBOUNDED,
+	"PRECEDING": PRECEDING,
+	"FOLLOWING": FOLLOWING,
+	"CURRENT":   CURRENT,
+	"ROW":       ROW,
+	"EXCLUDE":   EXCLUDE,
+	"TIES":      TIES,
+	"OTHERS":    OTHERS,
+	"NO":        NO,
 }
 
 var typeWithParenMap = map[string]TokenType{
@@ -417,7 +904,6 @@ var typeWithParenMap = map[string]TokenType{
 	"PERCENTILE_DISC": FUNCTION,
 	"GREATEST":        FUNCTION,
 	"LEAST":           FUNCTION,
-	"OVER":            FUNCTION,
 	"ROW_NUMBER":      FUNCTION,
 	"BIG":             TYPE,
 	"BIGSERIAL":       TYPE,
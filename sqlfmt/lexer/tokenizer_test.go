@@ -29,7 +29,7 @@ func TestGetTokens(t *testing.T) {
 		{Type: IDENT, Value: "xxx"},
 		{Type: AND, Value: "AND"},
 		{Type: IDENT, Value: "age"},
-		{Type: IDENT, Value: "="},
+		{Type: OPERATOR, Value: "="},
 		{Type: STRING, Value: "'xxx'"},
 		{Type: LIMIT, Value: "LIMIT"},
 		{Type: IDENT, Value: "100"},
@@ -47,6 +47,506 @@ func TestGetTokens(t *testing.T) {
 	}
 }
 
+func TestGetTokensOfDDL(t *testing.T) {
+	for _, tt := range ddlTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var ddlTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "DDL keywords in DDL statement",
+		src:  `create table xxx (key text)`,
+		want: []Token{
+			{Type: CREATE, Value: "CREATE"},
+			{Type: TABLE, Value: "TABLE"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: KEY, Value: "KEY"},
+			{Type: IDENT, Value: "TEXT"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "DDL keywords in the other statement",
+		src:  `select key from xxx`,
+		want: []Token{
+			{Type: SELECT, Value: "SELECT"},
+			{Type: IDENT, Value: "key"},
+			{Type: FROM, Value: "FROM"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "DDL keywords in query of view",
+		src:  `create view xxx as select key from xxx`,
+		want: []Token{
+			{Type: CREATE, Value: "CREATE"},
+			{Type: VIEW, Value: "VIEW"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: AS, Value: "AS"},
+			{Type: SELECT, Value: "SELECT"},
+			{Type: IDENT, Value: "key"},
+			{Type: FROM, Value: "FROM"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "keywords starting DDL statement in the other statement",
+		src:  `select drop, create from xxx where alter = 1`,
+		want: []Token{
+			{Type: SELECT, Value: "SELECT"},
+			{Type: IDENT, Value: "drop"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "create"},
+			{Type: FROM, Value: "FROM"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: WHERE, Value: "WHERE"},
+			{Type: IDENT, Value: "alter"},
+			{Type: OPERATOR, Value: "="},
+			{Type: IDENT, Value: "1"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "DROP in ALTER TABLE statement",
+		src:  `alter table xxx drop column xxx`,
+		want: []Token{
+			{Type: ALTER, Value: "ALTER"},
+			{Type: TABLE, Value: "TABLE"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: DROP, Value: "DROP"},
+			{Type: COLUMN, Value: "COLUMN"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensOfWindowSpec(t *testing.T) {
+	for _, tt := range windowSpecTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var windowSpecTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "window keywords in window specification",
+		src:  `select range over (partition by xxx rows current row) from xxx`,
+		want: []Token{
+			{Type: SELECT, Value: "SELECT"},
+			{Type: IDENT, Value: "range"},
+			{Type: OVER, Value: "OVER"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: PARTITION, Value: "PARTITION"},
+			{Type: BY, Value: "BY"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: ROWS, Value: "ROWS"},
+			{Type: CURRENT, Value: "CURRENT"},
+			{Type: ROW, Value: "ROW"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: FROM, Value: "FROM"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "window keywords in WINDOW clause",
+		src:  `window w as (partition by xxx) order by range`,
+		want: []Token{
+			{Type: WINDOW, Value: "WINDOW"},
+			{Type: IDENT, Value: "w"},
+			{Type: AS, Value: "AS"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: PARTITION, Value: "PARTITION"},
+			{Type: BY, Value: "BY"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: ORDER, Value: "ORDER"},
+			{Type: BY, Value: "BY"},
+			{Type: IDENT, Value: "range"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensOfFunctionCall(t *testing.T) {
+	for _, tt := range functionCallTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var functionCallTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "ident followed by parenthesis",
+		src:  `select lower(xxx), xxx.fn () from xxx`,
+		want: []Token{
+			{Type: SELECT, Value: "SELECT"},
+			{Type: FUNCTION, Value: "lower"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: IDENT, Value: "xxx"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "xxx.fn"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: FROM, Value: "FROM"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "table name followed by column list",
+		src:  `insert into xxx(xxx)`,
+		want: []Token{
+			{Type: INSERT, Value: "INSERT"},
+			{Type: INTO, Value: "INTO"},
+			{Type: IDENT, Value: "xxx"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: IDENT, Value: "xxx"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensOfOperator(t *testing.T) {
+	for _, tt := range operatorTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var operatorTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "binary operators",
+		src:  `a=b||c->>'d'`,
+		want: []Token{
+			{Type: IDENT, Value: "a"},
+			{Type: OPERATOR, Value: "="},
+			{Type: IDENT, Value: "b"},
+			{Type: OPERATOR, Value: "||"},
+			{Type: IDENT, Value: "c"},
+			{Type: OPERATOR, Value: "->>"},
+			{Type: STRING, Value: "'d'"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "prefix operator and type cast",
+		src:  `a=-1::int`,
+		want: []Token{
+			{Type: IDENT, Value: "a"},
+			{Type: OPERATOR, Value: "="},
+			{Type: IDENT, Value: "-1"},
+			{Type: IDENT, Value: "::int"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "prefix operator followed by delimiter",
+		src:  `xxx = -, (-), [-]`,
+		want: []Token{
+			{Type: IDENT, Value: "xxx"},
+			{Type: OPERATOR, Value: "="},
+			{Type: OPERATOR, Value: "-"},
+			{Type: COMMA, Value: ","},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: OPERATOR, Value: "-"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: COMMA, Value: ","},
+			{Type: STARTBRACKET, Value: "["},
+			{Type: OPERATOR, Value: "-"},
+			{Type: ENDBRACKET, Value: "]"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "type cast with whitespaces after ::",
+		src:  `xxx :: int, xxx ::  numeric(10, 2)`,
+		want: []Token{
+			{Type: IDENT, Value: "xxx"},
+			{Type: IDENT, Value: "::int"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "xxx"},
+			{Type: FUNCTION, Value: "::numeric"},
+			{Type: STARTPARENTHESIS, Value: "("},
+			{Type: IDENT, Value: "10"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "2"},
+			{Type: ENDPARENTHESIS, Value: ")"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "asterisk that is not operator",
+		src:  `select *, xxx.*`,
+		want: []Token{
+			{Type: SELECT, Value: "SELECT"},
+			{Type: IDENT, Value: "*"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "xxx.*"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensOfPlaceholder(t *testing.T) {
+	want := []Token{
+		{Type: PLACEHOLDER, Value: "$1"},
+		{Type: IDENT, Value: "::int"},
+		{Type: COMMA, Value: ","},
+		{Type: PLACEHOLDER, Value: "?"},
+		{Type: COMMA, Value: ","},
+		{Type: PLACEHOLDER, Value: ":xxx"},
+		{Type: COMMA, Value: ","},
+		{Type: PLACEHOLDER, Value: "@xxx"},
+		{Type: COMMA, Value: ","},
+		{Type: IDENT, Value: "@1"},
+		{Type: EOF, Value: "EOF"},
+	}
+	tnz := NewTokenizer(`$1::int, ?, :xxx, @xxx, @1`)
+	got, err := tnz.GetTokens()
+	if err != nil {
+		t.Fatalf("\nERROR: %#v", err)
+	} else if !reflect.DeepEqual(want, got) {
+		t.Errorf("\nwant %#v, \ngot %#v", want, got)
+	}
+}
+
+func TestGetTokensOfPrintfVerb(t *testing.T) {
+	want := []Token{
+		{Type: IDENT, Value: "xxx_%s"},
+		{Type: COMMA, Value: ","},
+		{Type: PLACEHOLDER, Value: "%[1]d"},
+		{Type: COMMA, Value: ","},
+		{Type: PLACEHOLDER, Value: "%-5.2f"},
+		{Type: COMMA, Value: ","},
+		{Type: IDENT, Value: "xxx"},
+		{Type: OPERATOR, Value: "%%"},
+		{Type: IDENT, Value: "2"},
+		{Type: COMMA, Value: ","},
+		{Type: IDENT, Value: "xxx"},
+		{Type: OPERATOR, Value: "%"},
+		{Type: IDENT, Value: "2"},
+		{Type: EOF, Value: "EOF"},
+	}
+	tnz := NewTokenizer(`xxx_%s, %[1]d, %-5.2f, xxx %% 2, xxx % 2`)
+	tnz.PrintfVerbs = true
+	got, err := tnz.GetTokens()
+	if err != nil {
+		t.Fatalf("\nERROR: %#v", err)
+	} else if !reflect.DeepEqual(want, got) {
+		t.Errorf("\nwant %#v, \ngot %#v", want, got)
+	}
+}
+
+func TestGetTokensOfDoubledQuote(t *testing.T) {
+	for _, tt := range doubledQuoteTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var doubledQuoteTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "doubled quotes in string and quoted identifier",
+		src:  `'it''s', "x""y"`,
+		want: []Token{
+			{Type: STRING, Value: "'it''s'"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: `"x""y"`},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "string of a single quote",
+		src:  `'''', xxx`,
+		want: []Token{
+			{Type: STRING, Value: "''''"},
+			{Type: COMMA, Value: ","},
+			{Type: IDENT, Value: "xxx"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "empty strings",
+		src:  `'' || ''`,
+		want: []Token{
+			{Type: STRING, Value: "''"},
+			{Type: OPERATOR, Value: "||"},
+			{Type: STRING, Value: "''"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensOfUnterminatedQuote(t *testing.T) {
+	for _, tt := range unterminatedQuoteTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokenizer(tt.src).GetTokens()
+			if err == nil {
+				t.Fatalf("unterminated quote of %#v should be error", tt.src)
+			}
+			if !strings.HasSuffix(err.Error(), tt.wantErr) {
+				t.Errorf("want %#v, got %#v", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+var unterminatedQuoteTestingData = []struct {
+	name    string
+	src     string
+	wantErr string
+}{
+	{
+		name:    "string",
+		src:     `select 'xxx`,
+		wantErr: `unterminated string 'xxx`,
+	},
+	{
+		name:    "string ending with doubled single quote",
+		src:     `select 'it''`,
+		wantErr: `unterminated string 'it''`,
+	},
+	{
+		name:    "quoted identifier",
+		src:     `select "xxx`,
+		wantErr: `unterminated quoted identifier "xxx`,
+	},
+}
+
+func TestGetTokensOfLineComment(t *testing.T) {
+	for _, tt := range lineCommentTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			tnz := NewTokenizer(tt.src)
+			got, err := tnz.GetTokens()
+			if err != nil {
+				t.Fatalf("\nERROR: %#v", err)
+			} else if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+var lineCommentTestingData = []struct {
+	name string
+	src  string
+	want []Token
+}{
+	{
+		name: "line comment right after ident",
+		src:  "xxx--comment",
+		want: []Token{
+			{Type: IDENT, Value: "xxx"},
+			{Type: IDENT, Value: "--comment"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "line comment right after number",
+		src:  "1-- comment",
+		want: []Token{
+			{Type: IDENT, Value: "1"},
+			{Type: IDENT, Value: "--"},
+			{Type: IDENT, Value: "comment"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+	{
+		name: "line comment after operator",
+		src:  "xxx = -- comment\n1",
+		want: []Token{
+			{Type: IDENT, Value: "xxx"},
+			{Type: OPERATOR, Value: "="},
+			{Type: IDENT, Value: "--"},
+			{Type: IDENT, Value: "comment"},
+			{Type: IDENT, Value: "1"},
+			{Type: EOF, Value: "EOF"},
+		},
+	},
+}
+
+func TestGetTokensWithOffsets(t *testing.T) {
+	src := "select xxx::int,\n  'あ' from xxx :: text where xxx = $1"
+	tnz := NewTokenizer(src)
+	tokens, offsets, err := tnz.GetTokensWithOffsets()
+	if err != nil {
+		t.Fatalf("\nERROR: %#v", err)
+	}
+	if len(tokens) != len(offsets) {
+		t.Fatalf("want %d offsets, got %d", len(tokens), len(offsets))
+	}
+
+	want := []int{0, 7, 10, 15, 19, 25, 30, 34, 42, 48, 52, 54, len(src)}
+	if !reflect.DeepEqual(want, offsets) {
+		t.Errorf("\nwant %#v, \ngot %#v", want, offsets)
+	}
+}
+
 func TestIsWhiteSpace(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+// Severity is the severity of Problem
+type Severity int
+
+// severities of Problem
+const (
+	Warning Severity = 1 + iota
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// ParseSeverity parses "warning" or "error" into Severity
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "warning":
+		return Warning, nil
+	case "error":
+		return Error, nil
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
+
+// Problem is a problem found in SQL statement by Rule
+type Problem struct {
+	Pos      token.Position
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", p.Pos, p.Severity, p.Message, p.Rule)
+}
+
+// Rule checks parsed SQL statement
+type Rule interface {
+	// Name is the name of the rule such as "select-star", which is used to enable or disable it
+	Name() string
+	// Severity is the default severity of problems found by the rule
+	Severity() Severity
+	// Check reports problems found in stmt by stmt.Reportf
+	Check(stmt *Statement)
+}
+
+// Linter checks SQL statements with enabled rules
+type Linter struct {
+	rules      []Rule
+	disabled   map[string]bool
+	severities map[string]Severity
+}
+
+// New returns Linter checking SQL statements with rules, all of which are enabled
+func New(rules ...Rule) *Linter {
+	return &Linter{
+		rules:      rules,
+		disabled:   map[string]bool{},
+		severities: map[string]Severity{},
+	}
+}
+
+// Rules returns rules of l including disabled ones
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// Enable enables rules of names
+func (l *Linter) Enable(names ...string) error {
+	for _, name := range names {
+		if err := l.lookup(name); err != nil {
+			return err
+		}
+		delete(l.disabled, name)
+	}
+	return nil
+}
+
+// Disable disables rules of names
+func (l *Linter) Disable(names ...string) error {
+	for _, name := range names {
+		if err := l.lookup(name); err != nil {
+			return err
+		}
+		l.disabled[name] = true
+	}
+	return nil
+}
+
+// SetSeverity overrides the severity of problems found by the rule of name
+func (l *Linter) SetSeverity(name string, severity Severity) error {
+	if err := l.lookup(name); err != nil {
+		return err
+	}
+	l.severities[name] = severity
+	return nil
+}
+
+func (l *Linter) lookup(name string) error {
+	for _, rule := range l.rules {
+		if rule.Name() == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown rule %q", name)
+}
+
+// Lint checks SQL statement src, returning problems whose positions are in src
+func (l *Linter) Lint(src string) ([]Problem, error) {
+	stmt, err := parse(src, false)
+	if err != nil {
+		return nil, err
+	}
+	return l.check(stmt, func(offset int) token.Position {
+		return position("", []byte(src), offset)
+	}), nil
+}
+
+// LintFile checks SQL statements passed to Query, QueryRow and Exec functions in .go file
+// SQL statements which can not be parsed are skipped, leaving them to sqlfmt
+func (l *Linter) LintFile(filename string, src []byte) ([]Problem, error) {
+	queries, err := sqlfmt.Queries(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	for _, q := range queries {
+		stmt, err := parse(q.SQL, q.PrintfVerbs)
+		if err != nil {
+			continue
+		}
+		q := q
+		stmt.Query = &q
+		problems = append(problems, l.check(stmt, func(offset int) token.Position {
+			// offset in interpreted string literal can not be mapped to .go file because of escape sequences
+			if !q.Raw {
+				return position(filename, src, q.Start)
+			}
+			return position(filename, src, q.Start+1+offset)
+		})...)
+	}
+	return problems, nil
+}
+
+// check applies enabled rules to stmt, converting offsets in stmt into positions by pos
+func (l *Linter) check(stmt *Statement, pos func(offset int) token.Position) []Problem {
+	var problems []Problem
+	for _, rule := range l.rules {
+		if l.disabled[rule.Name()] {
+			continue
+		}
+		name, severity := rule.Name(), rule.Severity()
+		if s, ok := l.severities[name]; ok {
+			severity = s
+		}
+		stmt.report = func(offset int, message string) {
+			problems = append(problems, Problem{Pos: pos(offset), Rule: name, Severity: severity, Message: message})
+		}
+		rule.Check(stmt)
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Pos.Offset < problems[j].Pos.Offset })
+	return problems
+}
+
+// position returns the position of offset in src, whose column is counted in bytes as go/token
+func position(filename string, src []byte, offset int) token.Position {
+	pos := token.Position{Filename: filename, Offset: offset, Line: 1, Column: 1}
+	for _, b := range src[:offset] {
+		if b == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	return pos
+}
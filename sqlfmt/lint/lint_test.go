@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintFile(t *testing.T) {
+	src := "package main\n\nfunc main() {\n" +
+		"\tdb.Exec(`\ndelete from xxx`)\n" +
+		"\tdb.Query(\"select * from xxx\")\n" +
+		"\tdb.Query(`select (xxx`)\n" +
+		"}\n"
+
+	l := New(Rules()...)
+	if err := l.SetSeverity("select-star", Error); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	problems, err := l.LintFile("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+
+	want := []string{
+		"main.go:5:1: error: DELETE without WHERE clause modifies all rows (missing-where)",
+		// position in interpreted string literal is the beginning of the literal
+		"main.go:6:11: error: SELECT * depends on the columns of the table, list columns explicitly (select-star)",
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("\nwant %#v, \ngot %#v", want, got)
+	}
+}
+
+func TestLinterConfig(t *testing.T) {
+	l := New(Rules()...)
+	if err := l.Disable("select-star", "limit-without-order-by"); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	if err := l.Enable("limit-without-order-by"); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	problems, err := l.Lint("select * from xxx limit 1")
+	if err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	if len(problems) != 1 || problems[0].Rule != "limit-without-order-by" || problems[0].Severity != Warning {
+		t.Errorf("want only limit-without-order-by, got %v", problems)
+	}
+
+	if err := l.Disable("xxx"); err == nil {
+		t.Errorf("unknown rule should be error")
+	}
+	if _, err := ParseSeverity("xxx"); err == nil {
+		t.Errorf("unknown severity should be error")
+	}
+}
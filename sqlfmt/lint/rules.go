@@ -0,0 +1,260 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+	"github.com/kanmu/go-sqlfmt/sqlfmt/parser/group"
+)
+
+// Rules returns built-in rules
+func Rules() []Rule {
+	return []Rule{
+		missingWhere{},
+		selectStar{},
+		implicitCrossJoin{},
+		notInSubquery{},
+		limitWithoutOrderBy{},
+		placeholderCount{},
+	}
+}
+
+// missingWhere finds UPDATE and DELETE statements without WHERE clause, which modify all rows
+type missingWhere struct{}
+
+func (missingWhere) Name() string       { return "missing-where" }
+func (missingWhere) Severity() Severity { return Error }
+
+func (missingWhere) Check(stmt *Statement) {
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		var (
+			target   *Node
+			hasWhere bool
+		)
+		// report reports target of the statement ending here, and starts the next statement
+		report := func() {
+			if target != nil && !hasWhere {
+				stmt.Reportf(target, "%s without WHERE clause modifies all rows", firstToken(target).Value)
+			}
+			target, hasWhere = nil, false
+		}
+		for i, n := range nodes {
+			switch n.Reindenter.(type) {
+			case *group.Update, *group.Delete:
+				// UPDATE of INSERT ... ON CONFLICT DO UPDATE updates only the conflicting row
+				if i == 0 || !endsWithToken(nodes[i-1], lexer.DO) {
+					report()
+					target = n
+				}
+			case *group.Where:
+				hasWhere = true
+			}
+			if endsWithSemicolon(n) {
+				report()
+			}
+		}
+		report()
+	})
+}
+
+// selectStar finds SELECT * and SELECT xxx.*, whose columns change with the table definition
+// SELECT * in EXISTS (subquery) is allowed
+type selectStar struct{}
+
+func (selectStar) Name() string       { return "select-star" }
+func (selectStar) Severity() Severity { return Warning }
+
+func (selectStar) Check(stmt *Statement) {
+	exists := map[*Node]bool{}
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		for i, n := range nodes {
+			if n.IsToken(lexer.EXISTS) && i+1 < len(nodes) {
+				exists[nodes[i+1]] = true
+			}
+		}
+	})
+
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		if exists[parent] {
+			return
+		}
+		for _, n := range nodes {
+			if _, ok := n.Reindenter.(*group.Select); !ok {
+				continue
+			}
+			for _, c := range n.Children {
+				if tok, ok := c.Token(); ok && (tok.Value == "*" || strings.HasSuffix(tok.Value, ".*")) {
+					stmt.Reportf(c, "SELECT %s depends on the columns of the table, list columns explicitly", tok.Value)
+				}
+			}
+		}
+	})
+}
+
+// implicitCrossJoin finds tables separated by comma in FROM clause such as FROM a, b
+type implicitCrossJoin struct{}
+
+func (implicitCrossJoin) Name() string       { return "implicit-cross-join" }
+func (implicitCrossJoin) Severity() Severity { return Warning }
+
+func (implicitCrossJoin) Check(stmt *Statement) {
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		for _, n := range nodes {
+			if _, ok := n.Reindenter.(*group.From); !ok {
+				continue
+			}
+			for _, c := range n.Children {
+				if c.IsToken(lexer.COMMA) {
+					stmt.Reportf(c, "implicit cross join by comma in FROM clause, use JOIN instead")
+				}
+			}
+		}
+	})
+}
+
+// notInSubquery finds NOT IN (subquery), which returns no rows if the subquery returns NULL
+type notInSubquery struct{}
+
+func (notInSubquery) Name() string       { return "not-in-subquery" }
+func (notInSubquery) Severity() Severity { return Warning }
+
+func (notInSubquery) Check(stmt *Statement) {
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		for i := 0; i+2 < len(nodes); i++ {
+			if !nodes[i].IsToken(lexer.NOT) || !nodes[i+1].IsToken(lexer.IN) {
+				continue
+			}
+			if _, ok := nodes[i+2].Reindenter.(*group.Subquery); ok {
+				stmt.Reportf(nodes[i], "NOT IN (subquery) returns no rows if the subquery returns NULL, use NOT EXISTS instead")
+			}
+		}
+	})
+}
+
+// limitWithoutOrderBy finds LIMIT and FETCH without ORDER BY, which return arbitrary rows
+type limitWithoutOrderBy struct{}
+
+func (limitWithoutOrderBy) Name() string       { return "limit-without-order-by" }
+func (limitWithoutOrderBy) Severity() Severity { return Warning }
+
+func (limitWithoutOrderBy) Check(stmt *Statement) {
+	stmt.Walk(func(parent *Node, nodes []*Node) {
+		var (
+			limit      *Node
+			hasOrderBy bool
+		)
+		for _, n := range nodes {
+			switch n.Reindenter.(type) {
+			case *group.LimitClause:
+				if ttype := firstToken(n).Type; ttype == lexer.LIMIT || ttype == lexer.FETCH {
+					limit = n
+				}
+			case *group.OrderBy:
+				hasOrderBy = true
+			}
+		}
+		if limit != nil && !hasOrderBy {
+			stmt.Reportf(limit, "%s without ORDER BY returns arbitrary rows", firstToken(limit).Value)
+		}
+	})
+}
+
+// placeholderCount finds calls of Query, QueryRow and Exec functions whose number of arguments differs from placeholders
+// positional placeholders such as $1, ?1 are counted by the max number and ? is counted by occurrences
+// statements with named placeholders such as :name, @name are not checked
+type placeholderCount struct{}
+
+func (placeholderCount) Name() string       { return "placeholder-count" }
+func (placeholderCount) Severity() Severity { return Error }
+
+func (placeholderCount) Check(stmt *Statement) {
+	q := stmt.Query
+	if q == nil || len(stmt.Nodes) == 0 {
+		return
+	}
+
+	var (
+		// required is the number of arguments required by placeholders
+		required int
+		// exceeding is the first placeholder which requires more arguments than passed
+		exceeding *Node
+		numbered  bool
+		sequence  bool
+	)
+	// the slice passed with ... can fill any number of arguments
+	passed := q.Args
+	if q.Variadic {
+		passed--
+	}
+	for _, n := range stmt.Tokens(lexer.PLACEHOLDER) {
+		tok, _ := n.Token()
+		switch {
+		// printf verbs in templates of fmt.Sprintf are not bind parameters
+		case strings.HasPrefix(tok.Value, "%"):
+			continue
+		case tok.Value == "?":
+			sequence = true
+			required++
+		case strings.HasPrefix(tok.Value, "$") || strings.HasPrefix(tok.Value, "?"):
+			numbered = true
+			if i, err := strconv.Atoi(tok.Value[1:]); err == nil && i > required {
+				required = i
+			}
+		default:
+			return
+		}
+		if numbered && sequence {
+			return
+		}
+		if exceeding == nil && required > passed && !q.Variadic {
+			exceeding = n
+		}
+	}
+
+	switch {
+	case exceeding != nil && numbered:
+		tok, _ := exceeding.Token()
+		stmt.Reportf(exceeding, "%s is used but %s passed to %s", tok.Value, plural(q.Args, "argument"), q.Func)
+	case exceeding != nil:
+		stmt.Reportf(exceeding, "%s used but %s passed to %s", plural(required, "placeholder"), plural(q.Args, "argument"), q.Func)
+	case passed > required && q.Variadic:
+		stmt.Reportf(stmt.Nodes[0], "%s used but at least %s passed to %s", plural(required, "placeholder"), plural(passed, "argument"), q.Func)
+	case passed > required:
+		stmt.Reportf(stmt.Nodes[0], "%s used but %s passed to %s", plural(required, "placeholder"), plural(passed, "argument"), q.Func)
+	}
+}
+
+// plural returns the count of nouns such as "1 argument is", "2 arguments are"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun + " is"
+	}
+	return strconv.Itoa(n) + " " + noun + "s are"
+}
+
+// endsWithToken returns true if the last token in n is ttype
+func endsWithToken(n *Node, ttype lexer.TokenType) bool {
+	for len(n.Children) > 0 {
+		n = n.Children[len(n.Children)-1]
+	}
+	return n.IsToken(ttype)
+}
+
+// endsWithSemicolon returns true if n ends with ";", which is scanned as a part of the last token such as 1;
+func endsWithSemicolon(n *Node) bool {
+	for len(n.Children) > 0 {
+		n = n.Children[len(n.Children)-1]
+	}
+	tok, ok := n.Token()
+	return ok && strings.HasSuffix(tok.Value, ";")
+}
+
+// firstToken returns the first token in n
+func firstToken(n *Node) lexer.Token {
+	for len(n.Children) > 0 {
+		n = n.Children[0]
+	}
+	tok, _ := n.Token()
+	return tok
+}
@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		src  string
+		// want holds offsets of problems
+		want []int
+	}{
+		{rule: "missing-where", src: "update xxx set xxx = 1", want: []int{0}},
+		{rule: "missing-where", src: "delete from xxx", want: []int{0}},
+		{rule: "missing-where", src: "update xxx set xxx = 1 where xxx = $1", want: nil},
+		{rule: "missing-where", src: "delete from xxx where xxx = $1", want: nil},
+		{rule: "missing-where", src: "insert into xxx (xxx) values ($1) on conflict (xxx) do update set xxx = $1", want: nil},
+		{rule: "missing-where", src: "update xxx set xxx = 1 where xxx = $1; update yyy set yyy = 2", want: []int{39}},
+		{rule: "missing-where", src: "update xxx set xxx = 1; delete from yyy where yyy = $1", want: []int{0}},
+		{rule: "missing-where", src: "delete from xxx; delete from yyy", want: []int{0, 17}},
+		{rule: "missing-where", src: "update xxx set xxx = 1; select xxx from yyy where yyy = $1", want: []int{0}},
+		{rule: "select-star", src: "select * from xxx", want: []int{7}},
+		{rule: "select-star", src: "select xxx, a.* from xxx a", want: []int{12}},
+		{rule: "select-star", src: "select count(*) from xxx", want: nil},
+		{rule: "select-star", src: "select xxx from xxx where exists (select * from yyy)", want: nil},
+		{rule: "select-star", src: "select xxx from (select * from yyy) a", want: []int{24}},
+		{rule: "implicit-cross-join", src: "select xxx from a, b", want: []int{17}},
+		{rule: "implicit-cross-join", src: "select xxx, yyy from a join b on a.id = b.id", want: nil},
+		{rule: "not-in-subquery", src: "select xxx from xxx where xxx not in (select xxx from yyy)", want: []int{30}},
+		{rule: "not-in-subquery", src: "select xxx from xxx where xxx not in (1, 2)", want: nil},
+		{rule: "not-in-subquery", src: "select xxx from xxx where not exists (select xxx from yyy)", want: nil},
+		{rule: "limit-without-order-by", src: "select xxx from xxx limit 1", want: []int{20}},
+		{rule: "limit-without-order-by", src: "select xxx from xxx order by xxx limit 1", want: nil},
+		{rule: "limit-without-order-by", src: "select xxx from (select xxx from xxx limit 1) a order by xxx", want: []int{37}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule+": "+tt.src, func(t *testing.T) {
+			l := New(Rules()...)
+			for _, rule := range l.Rules() {
+				if rule.Name() != tt.rule {
+					l.Disable(rule.Name())
+				}
+			}
+			problems, err := l.Lint(tt.src)
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+
+			var got []int
+			for _, p := range problems {
+				got = append(got, p.Pos.Offset)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("want %#v, got %#v: %v", tt.want, got, problems)
+			}
+		})
+	}
+}
+
+func TestPlaceholderCount(t *testing.T) {
+	tests := []struct {
+		name string
+		call string
+		want []string
+	}{
+		{name: "numbered", call: "db.Query(`select xxx from xxx where a = $1 and b = $2 or c = $1`, a, b)", want: nil},
+		{name: "numbered with too few arguments", call: "db.Query(`select xxx from xxx where a = $1 and b = $3`, a, b)", want: []string{"main.go:4:53: error: $3 is used but 2 arguments are passed to Query (placeholder-count)"}},
+		{name: "numbered with too many arguments", call: "db.Exec(`delete from xxx where a = $1`, a, b)", want: []string{"main.go:4:11: error: 1 placeholder is used but 2 arguments are passed to Exec (placeholder-count)"}},
+		{name: "sequence", call: "db.Exec(`update xxx set a = ? where b = ?`, a, b)", want: nil},
+		{name: "sequence with too few arguments", call: "db.Exec(`update xxx set a = ? where b = ?`, a)", want: []string{"main.go:4:42: error: 2 placeholders are used but 1 argument is passed to Exec (placeholder-count)"}},
+		{name: "no placeholder with arguments", call: "db.QueryRow(`select xxx from xxx`, a)", want: []string{"main.go:4:15: error: 0 placeholders are used but 1 argument is passed to QueryRow (placeholder-count)"}},
+		{name: "variadic", call: "db.Query(`select xxx from xxx where a = $1 and b = $2`, args...)", want: nil},
+		{name: "variadic with too many arguments", call: "db.Query(`select xxx from xxx where a = $1`, a, b, args...)", want: []string{"main.go:4:12: error: 1 placeholder is used but at least 2 arguments are passed to Query (placeholder-count)"}},
+		{name: "named", call: "db.Exec(`update xxx set a = :a`, x)", want: nil},
+		{name: "printf verbs", call: "db.Query(fmt.Sprintf(`select xxx from %s where a = $1`, table), a)", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(placeholderCount{})
+			src := "package main\n\nfunc main() {\n\t" + tt.call + "\n}\n"
+			problems, err := l.LintFile("main.go", []byte(src))
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+
+			var got []string
+			for _, p := range problems {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
+	}
+}
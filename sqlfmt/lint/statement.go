@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+	"github.com/kanmu/go-sqlfmt/sqlfmt/parser"
+	"github.com/kanmu/go-sqlfmt/sqlfmt/parser/group"
+	"github.com/pkg/errors"
+)
+
+// Statement is a parsed SQL statement checked by rules
+type Statement struct {
+	// Nodes are clause groups and tokens at the top level
+	Nodes []*Node
+	// Query is the call of Query, QueryRow or Exec function passing the statement in .go file
+	// it is nil if the statement is not in .go file
+	Query *sqlfmt.Query
+
+	report func(offset int, message string)
+}
+
+// Node is a clause group or a token of parsed SQL statement
+type Node struct {
+	// Reindenter is a clause group such as *group.Select, or lexer.Token
+	group.Reindenter
+	// Offset is the byte offset of the first token of the node in SQL statement
+	Offset int
+	// Children are elements of the clause group
+	Children []*Node
+}
+
+// Token returns the token of n, or false if n is a clause group
+func (n *Node) Token() (lexer.Token, bool) {
+	tok, ok := n.Reindenter.(lexer.Token)
+	return tok, ok
+}
+
+// IsToken returns true if n is a token of ttype
+func (n *Node) IsToken(ttype lexer.TokenType) bool {
+	tok, ok := n.Token()
+	return ok && tok.Type == ttype
+}
+
+// Reportf reports a problem at node
+func (s *Statement) Reportf(node *Node, format string, args ...interface{}) {
+	s.report(node.Offset, fmt.Sprintf(format, args...))
+}
+
+// Tokens returns nodes of tokens of ttype in order of appearance
+func (s *Statement) Tokens(ttype lexer.TokenType) []*Node {
+	var result []*Node
+	s.Walk(func(parent *Node, nodes []*Node) {
+		for _, n := range nodes {
+			if n.IsToken(ttype) {
+				result = append(result, n)
+			}
+		}
+	})
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Offset < result[j].Offset })
+	return result
+}
+
+// Walk calls f with nodes at the top level and children of each clause group in depth-first order
+// parent is the clause group of nodes, or nil at the top level
+func (s *Statement) Walk(f func(parent *Node, nodes []*Node)) {
+	walk(nil, s.Nodes, f)
+}
+
+func walk(parent *Node, nodes []*Node, f func(parent *Node, nodes []*Node)) {
+	f(parent, nodes)
+	for _, n := range nodes {
+		if len(n.Children) > 0 {
+			walk(n, n.Children, f)
+		}
+	}
+}
+
+// parse tokenizes and parses src into Statement
+// if printfVerbs is true, printf verbs in src are scanned as placeholders
+func parse(src string, printfVerbs bool) (*Statement, error) {
+	t := lexer.NewTokenizer(src)
+	t.PrintfVerbs = printfVerbs
+	tokens, offsets, err := t.GetTokensWithOffsets()
+	if err != nil {
+		return nil, errors.Wrap(err, "Tokenize failed")
+	}
+
+	var (
+		nodes []*Node
+		i     int
+	)
+	// statements separated by ";" are parsed one by one so that each statement starts its own clause groups
+	for _, tokens := range splitStatements(tokens) {
+		rs, err := parser.ParseTokens(tokens)
+		if err != nil {
+			return nil, errors.Wrap(err, "ParseTokens failed")
+		}
+		nodes = append(nodes, newNodes(rs, offsets, &i)...)
+	}
+	return &Statement{Nodes: nodes}, nil
+}
+
+// splitStatements splits tokens into statements ending with EOF
+// ";" is scanned as a part of the last token of the statement such as 1;
+func splitStatements(tokens []lexer.Token) [][]lexer.Token {
+	var (
+		result [][]lexer.Token
+		start  int
+	)
+	for i, tok := range tokens {
+		if tok.Type != lexer.EOF && !strings.HasSuffix(tok.Value, ";") {
+			continue
+		}
+		if tok.Type != lexer.EOF {
+			i++
+		}
+		// tokens without statements are parsed as they are so that ParseTokens returns the error
+		if i > start || len(result) == 0 {
+			stmt := append(tokens[start:i:i], lexer.Token{Type: lexer.EOF, Value: "EOF"})
+			result = append(result, stmt)
+		}
+		start = i
+	}
+	return result
+}
+
+// newNodes makes nodes of rs, whose tokens appear in the same order as offsets from *i
+func newNodes(rs []group.Reindenter, offsets []int, i *int) []*Node {
+	var nodes []*Node
+	for _, r := range rs {
+		n := &Node{Reindenter: r}
+		if *i < len(offsets) {
+			n.Offset = offsets[*i]
+		}
+		if _, ok := r.(lexer.Token); ok {
+			*i++
+		} else {
+			n.Children = newNodes(group.Elements(r), offsets, i)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"strings"
+)
+
+// positionOf converts byte offset in text into Position
+func positionOf(text string, offset int) Position {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	start := strings.LastIndexByte(text[:offset], '\n') + 1
+
+	var character int
+	for _, r := range text[start:offset] {
+		character += utf16Len(r)
+	}
+	return Position{Line: strings.Count(text[:start], "\n"), Character: character}
+}
+
+// offsetOf converts pos into byte offset in text
+// pos beyond the end of the line is regarded as the end of the line
+func offsetOf(text string, pos Position) int {
+	var start int
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(text[start:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		start += i + 1
+	}
+
+	var character int
+	for i, r := range text[start:] {
+		if character >= pos.Character || r == '\n' {
+			return start + i
+		}
+		character += utf16Len(r)
+	}
+	return len(text)
+}
+
+func rangeOf(text string, start, end int) Range {
+	return Range{Start: positionOf(text, start), End: positionOf(text, end)}
+}
+
+// utf16Len returns the number of UTF-16 code units of r
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
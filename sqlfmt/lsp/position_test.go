@@ -0,0 +1,72 @@
+package lsp
+
+import (
+	"testing"
+)
+
+func TestPosition(t *testing.T) {
+	text := "select\n  'あ𠮷', xxx\nfrom xxx"
+	tests := []struct {
+		name   string
+		offset int
+		want   Position
+	}{
+		{
+			name:   "beginning of text",
+			offset: 0,
+			want:   Position{Line: 0, Character: 0},
+		},
+		{
+			name:   "beginning of line",
+			offset: 7,
+			want:   Position{Line: 1, Character: 0},
+		},
+		{
+			name:   "after multi-byte characters",
+			offset: 17,
+			want:   Position{Line: 1, Character: 6},
+		},
+		{
+			name:   "end of text",
+			offset: len(text),
+			want:   Position{Line: 2, Character: 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := positionOf(text, tt.offset); got != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+			if got := offsetOf(text, tt.want); got != tt.offset {
+				t.Errorf("want %d, got %d", tt.offset, got)
+			}
+		})
+	}
+}
+
+func TestOffsetOfOutOfRange(t *testing.T) {
+	text := "select\nxxx"
+	tests := []struct {
+		name string
+		pos  Position
+		want int
+	}{
+		{
+			name: "beyond the end of line",
+			pos:  Position{Line: 0, Character: 100},
+			want: 6,
+		},
+		{
+			name: "beyond the last line",
+			pos:  Position{Line: 5, Character: 0},
+			want: len(text),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := offsetOf(text, tt.pos); got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+	"github.com/pkg/errors"
+)
+
+// Server is a language server formatting SQL statements in .go and .sql files
+type Server struct {
+	options *sqlfmt.Options
+	// docs holds texts of opened documents by URI
+	docs map[string]string
+	out  io.Writer
+}
+
+// NewServer returns Server formatting SQL statements with options
+func NewServer(options *sqlfmt.Options) *Server {
+	return &Server{
+		options: options,
+		docs:    map[string]string{},
+	}
+}
+
+// Serve reads messages from in and writes messages to out until in is closed or exit notification is received
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		content, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "readMessage failed")
+		}
+
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		// responses from the client are ignored because the server sends no request
+		if req.Method == "" {
+			continue
+		}
+
+		result, rerr := s.handleSafely(&req)
+		if req.ID == nil {
+			if rerr != nil {
+				log.Println(rerr)
+			}
+			continue
+		}
+		if err := s.reply(req.ID, result, rerr); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rerr *responseError) error {
+	res := &response{JSONRPC: "2.0", ID: id, Error: rerr}
+	if rerr == nil {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return errors.Wrap(err, "json.Marshal failed")
+		}
+		res.Result = b
+	}
+	return writeMessage(s.out, res)
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return writeMessage(s.out, &notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// handleSafely calls handle, recovering from a panic into the internal error so that the session is kept
+func (s *Server) handleSafely(req *request) (result interface{}, rerr *responseError) {
+	defer func() {
+		if p := recover(); p != nil {
+			result, rerr = nil, &responseError{Code: codeInternalError, Message: fmt.Sprintf("%s panicked: %v", req.Method, p)}
+		}
+	}()
+	return s.handle(req)
+}
+
+func (s *Server) handle(req *request) (interface{}, *responseError) {
+	switch req.Method {
+	case "initialize":
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:                syncFull,
+				DocumentFormattingProvider:      true,
+				DocumentRangeFormattingProvider: true,
+			},
+			ServerInfo: serverInfo{Name: "sqlfmt"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if rerr := unmarshalParams(req, &params); rerr != nil {
+			return nil, rerr
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if rerr := unmarshalParams(req, &params); rerr != nil {
+			return nil, rerr
+		}
+		// the whole text is sent on each change because textDocumentSync is full
+		for _, change := range params.ContentChanges {
+			s.docs[params.TextDocument.URI] = change.Text
+		}
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if rerr := unmarshalParams(req, &params); rerr != nil {
+			return nil, rerr
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/formatting":
+		var params formattingParams
+		if rerr := unmarshalParams(req, &params); rerr != nil {
+			return nil, rerr
+		}
+		text, rerr := s.document(params.TextDocument.URI)
+		if rerr != nil {
+			return nil, rerr
+		}
+		return s.textEdits(params.TextDocument.URI, text, 0, len(text))
+	case "textDocument/rangeFormatting":
+		var params rangeFormattingParams
+		if rerr := unmarshalParams(req, &params); rerr != nil {
+			return nil, rerr
+		}
+		text, rerr := s.document(params.TextDocument.URI)
+		if rerr != nil {
+			return nil, rerr
+		}
+		start, end := offsetOf(text, params.Range.Start), offsetOf(text, params.Range.End)
+		if start > end {
+			return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid range: start %d:%d is after end %d:%d",
+				params.Range.Start.Line, params.Range.Start.Character, params.Range.End.Line, params.Range.End.Character)}
+		}
+		return s.textEdits(params.TextDocument.URI, text, start, end)
+	}
+
+	// notifications such as initialized and $/cancelRequest are ignored
+	if req.ID == nil {
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s is not supported", req.Method)}
+}
+
+func unmarshalParams(req *request, v interface{}) *responseError {
+	if err := json.Unmarshal(req.Params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) document(uri string) (string, *responseError) {
+	text, ok := s.docs[uri]
+	if !ok {
+		return "", &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("document %s is not opened", uri)}
+	}
+	return text, nil
+}
+
+// textEdits returns edits formatting SQL statements between start and end of the document
+// in .go file, each string literal overlapping the range is formatted
+// in .sql file, the text in the range is formatted as a SQL statement
+func (s *Server) textEdits(uri, text string, start, end int) ([]TextEdit, *responseError) {
+	// failures of string literals are reported by diagnostics, not by logs
+	opt := *s.options
+	opt.ErrorHandler = func(*sqlfmt.LiteralError) {}
+
+	result := []TextEdit{}
+	switch path.Ext(uri) {
+	case ".go":
+		edits, err := sqlfmt.EditsRange(filename(uri), []byte(text), start, end, &opt)
+		if err != nil {
+			return nil, &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		for _, e := range edits {
+			if text[e.Start:e.End] == e.Text {
+				continue
+			}
+			result = append(result, TextEdit{Range: rangeOf(text, e.Start, e.End), NewText: e.Text})
+		}
+	case ".sql":
+		src := text[start:end]
+		if strings.TrimSpace(src) == "" {
+			return result, nil
+		}
+		res, err := sqlfmt.Format(src, &opt)
+		if err != nil {
+			return nil, &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		// white spaces around the statement are kept as they are
+		leading := src[:len(src)-len(strings.TrimLeft(src, " \t\r\n"))]
+		trailing := src[len(strings.TrimRight(src, " \t\r\n")):]
+		if res = leading + strings.Trim(res, "\n") + trailing; res != src {
+			result = append(result, TextEdit{Range: rangeOf(text, start, end), NewText: res})
+		}
+	default:
+		return nil, &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("document %s is neither .go nor .sql file", uri)}
+	}
+	return result, nil
+}
+
+// publishDiagnostics sends diagnostics of SQL statements which failed to be formatted in the document
+func (s *Server) publishDiagnostics(uri string) *responseError {
+	diagnostics := []Diagnostic{}
+	if text, ok := s.docs[uri]; ok {
+		diagnostics = s.diagnostics(uri, text)
+	}
+	if err := s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics}); err != nil {
+		return &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) diagnostics(uri, text string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	switch path.Ext(uri) {
+	case ".go":
+		opt := *s.options
+		opt.ErrorHandler = func(err *sqlfmt.LiteralError) {
+			diagnostics = append(diagnostics, newDiagnostic(rangeOf(text, err.Start.Offset, err.End.Offset), err.Err))
+		}
+		// syntax errors of Go code are left to the other tools
+		sqlfmt.Edits(filename(uri), []byte(text), &opt)
+	case ".sql":
+		if strings.TrimSpace(text) == "" {
+			break
+		}
+		if _, err := sqlfmt.Format(text, s.options); err != nil {
+			diagnostics = append(diagnostics, newDiagnostic(rangeOf(text, 0, len(text)), err))
+		}
+	}
+	return diagnostics
+}
+
+func newDiagnostic(r Range, err error) Diagnostic {
+	return Diagnostic{Range: r, Severity: severityWarning, Source: "sqlfmt", Message: err.Error()}
+}
+
+// filename returns the path of file URI, or uri itself if it is not file URI
+func filename(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	return uri
+}
@@ -0,0 +1,229 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt"
+)
+
+const goSrc = "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Exec(`select xxx from (xxx`)\n}\n"
+
+// serve sends messages to Server and returns messages it sent back
+func serve(t *testing.T, messages ...string) []map[string]interface{} {
+	t.Helper()
+
+	var in, out bytes.Buffer
+	for _, m := range messages {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	if err := NewServer(&sqlfmt.Options{}).Serve(&in, &out); err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+
+	var result []map[string]interface{}
+	r := bufio.NewReader(&out)
+	for {
+		content, err := readMessage(r)
+		if err == io.EOF {
+			return result
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(content, &m); err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, m)
+	}
+}
+
+func didOpen(uri, text string) string {
+	b, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/didOpen",
+		"params":  map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri, "languageId": "go", "version": 1, "text": text}},
+	})
+	return string(b)
+}
+
+// applyTextEdits applies edits in the result of formatting request to text
+func applyTextEdits(t *testing.T, text string, result interface{}) string {
+	t.Helper()
+
+	b, _ := json.Marshal(result)
+	var edits []TextEdit
+	if err := json.Unmarshal(b, &edits); err != nil {
+		t.Fatal(err)
+	}
+	for i := len(edits) - 1; i >= 0; i-- {
+		start, end := offsetOf(text, edits[i].Range.Start), offsetOf(text, edits[i].Range.End)
+		text = text[:start] + edits[i].NewText + text[end:]
+	}
+	return text
+}
+
+func TestServeInitialize(t *testing.T) {
+	got := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"xxx"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`,
+	)
+	if len(got) != 3 {
+		t.Fatalf("want 3 responses, got %#v", got)
+	}
+
+	want := map[string]interface{}{
+		"textDocumentSync":                float64(syncFull),
+		"documentFormattingProvider":      true,
+		"documentRangeFormattingProvider": true,
+	}
+	if capabilities := got[0]["result"].(map[string]interface{})["capabilities"]; !reflect.DeepEqual(want, capabilities) {
+		t.Errorf("want %#v, got %#v", want, capabilities)
+	}
+	if code := got[1]["error"].(map[string]interface{})["code"]; code != float64(codeMethodNotFound) {
+		t.Errorf("want %d, got %v", codeMethodNotFound, code)
+	}
+	if result, ok := got[2]["result"]; !ok || result != nil {
+		t.Errorf("want null result, got %#v", got[2])
+	}
+}
+
+func TestServeFormatting(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		text    string
+		request string
+		want    string
+	}{
+		{
+			name:    "formatting of .go file",
+			uri:     "file:///xxx/main.go",
+			text:    goSrc,
+			request: `{"jsonrpc":"2.0","id":1,"method":"textDocument/formatting","params":{"textDocument":{"uri":"file:///xxx/main.go"},"options":{"tabSize":4,"insertSpaces":false}}}`,
+			want:    "package main\n\nfunc main() {\n\tdb.Query(`\nSELECT\n  xxx\nFROM xxx`)\n\tdb.Exec(`select xxx from (xxx`)\n}\n",
+		},
+		{
+			name:    "range formatting of .go file outside of SQL statements",
+			uri:     "file:///xxx/main.go",
+			text:    goSrc,
+			request: `{"jsonrpc":"2.0","id":1,"method":"textDocument/rangeFormatting","params":{"textDocument":{"uri":"file:///xxx/main.go"},"range":{"start":{"line":0,"character":0},"end":{"line":2,"character":0}}}}`,
+			want:    goSrc,
+		},
+		{
+			name:    "formatting of .sql file",
+			uri:     "file:///xxx/query.sql",
+			text:    "select xxx from xxx\n",
+			request: `{"jsonrpc":"2.0","id":1,"method":"textDocument/formatting","params":{"textDocument":{"uri":"file:///xxx/query.sql"}}}`,
+			want:    "SELECT\n  xxx\nFROM xxx\n",
+		},
+		{
+			name:    "range formatting of .sql file",
+			uri:     "file:///xxx/query.sql",
+			text:    "-- xxx\nselect xxx from xxx;\n",
+			request: `{"jsonrpc":"2.0","id":1,"method":"textDocument/rangeFormatting","params":{"textDocument":{"uri":"file:///xxx/query.sql"},"range":{"start":{"line":1,"character":0},"end":{"line":1,"character":19}}}}`,
+			want:    "-- xxx\nSELECT\n  xxx\nFROM xxx;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serve(t, didOpen(tt.uri, tt.text), tt.request)
+			if len(got) != 2 {
+				t.Fatalf("want diagnostics and response, got %#v", got)
+			}
+			if res := applyTextEdits(t, tt.text, got[1]["result"]); res != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, res)
+			}
+		})
+	}
+}
+
+func TestServeDiagnostics(t *testing.T) {
+	got := serve(t,
+		didOpen("file:///xxx/main.go", goSrc),
+		`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"file:///xxx/main.go","version":2},"contentChanges":[{"text":"package main\n"}]}}`,
+	)
+	if len(got) != 2 {
+		t.Fatalf("want 2 notifications, got %#v", got)
+	}
+
+	b, _ := json.Marshal(got[0]["params"])
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(b, &params); err != nil {
+		t.Fatal(err)
+	}
+	if len(params.Diagnostics) != 1 {
+		t.Fatalf("want 1 diagnostic, got %#v", params.Diagnostics)
+	}
+	wantRange := Range{Start: Position{Line: 4, Character: 9}, End: Position{Line: 4, Character: 31}}
+	if d := params.Diagnostics[0]; d.Range != wantRange || !strings.Contains(d.Message, "ParseTokens failed") {
+		t.Errorf("want diagnostic at %#v with the reason, got %#v", wantRange, d)
+	}
+
+	// diagnostics are cleared after the statement is fixed
+	if diagnostics := got[1]["params"].(map[string]interface{})["diagnostics"]; !reflect.DeepEqual(diagnostics, []interface{}{}) {
+		t.Errorf("want empty diagnostics, got %#v", diagnostics)
+	}
+}
+
+func TestServeInvalidRequest(t *testing.T) {
+	t.Run("reversed range", func(t *testing.T) {
+		got := serve(t,
+			didOpen("file:///xxx/query.sql", "-- xxx\nselect xxx from xxx;\n"),
+			`{"jsonrpc":"2.0","id":1,"method":"textDocument/rangeFormatting","params":{"textDocument":{"uri":"file:///xxx/query.sql"},"range":{"start":{"line":1,"character":0},"end":{"line":0,"character":3}}}}`,
+		)
+		if len(got) != 2 {
+			t.Fatalf("want diagnostics and response, got %#v", got)
+		}
+		if code := got[1]["error"].(map[string]interface{})["code"]; code != float64(codeInvalidParams) {
+			t.Errorf("want %d, got %v", codeInvalidParams, code)
+		}
+	})
+
+	t.Run("panic in handler", func(t *testing.T) {
+		var in, out bytes.Buffer
+		for _, m := range []string{
+			`{"jsonrpc":"2.0","id":1,"method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///xxx/query.sql","text":"select xxx"}}}`,
+			`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		} {
+			fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(m), m)
+		}
+		// the server without documents map panics on opening a document
+		s := &Server{options: &sqlfmt.Options{}}
+		if err := s.Serve(&in, &out); err != nil {
+			t.Fatalf("should be nil, got %v", err)
+		}
+		r := bufio.NewReader(&out)
+		for _, want := range []string{`"code":-32603`, `"result":null`} {
+			content, err := readMessage(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), want) {
+				t.Errorf("want %s, got %s", want, content)
+			}
+		}
+	})
+}
+
+func TestReadMessageInvalidLength(t *testing.T) {
+	for _, length := range []string{"-1", "xxx", fmt.Sprint(maxContentLength + 1)} {
+		t.Run(length, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader("Content-Length: " + length + "\r\n\r\n{}"))
+			if _, err := readMessage(r); err == nil {
+				t.Errorf("should be error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package sqlfmt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+	"github.com/pkg/errors"
+)
+
+// Normalize returns the shape of src, which is the same among SQL statements differing only in values, comments and layout
+// literals and placeholders are replaced with ?, lists of IN (...) are collapsed into IN (?), comments are removed,
+// keywords are written in upper case as tokenized and tokens are separated by a single space
+func Normalize(src string) (string, error) {
+	return NormalizeWithOptions(src, &Options{})
+}
+
+// NormalizeWithOptions normalizes src like Normalize
+// if PrintfVerbs is true, printf verbs in the template of fmt.Sprintf such as %s are kept as they are
+func NormalizeWithOptions(src string, options *Options) (string, error) {
+	src = strings.TrimRight(strings.TrimSpace(src), ";")
+	tokens, offsets, err := newTokenizer(src, options).GetTokensWithOffsets()
+	if err != nil {
+		return "", errors.Wrap(err, "Tokenize failed")
+	}
+
+	var (
+		result     []lexer.Token
+		commentEnd int
+	)
+	for i, tok := range tokens {
+		switch {
+		case tok.Type == lexer.EOF || offsets[i] < commentEnd:
+			continue
+		case isLineComment(tok) || isBlockCommentStart(tok):
+			commentEnd = commentEndOffset(src, tok, offsets[i])
+			continue
+		case tok.Type == lexer.PLACEHOLDER && options.PrintfVerbs && strings.HasPrefix(tok.Value, "%"):
+			// printf verb is kept because it is often a part of SQL statement such as a table name
+		case isLiteral(tok):
+			// prefix of string literal such as E'...' and X'...' is a part of the literal
+			if tok.Type == lexer.STRING && i > 0 && isStringPrefix(tokens[i-1]) && offsets[i-1]+len(tokens[i-1].Value) == offsets[i] {
+				result = result[:len(result)-1]
+			}
+			tok = lexer.Token{Type: lexer.PLACEHOLDER, Value: "?"}
+		}
+		result = append(result, tok)
+		if tok.Type == lexer.ENDPARENTHESIS {
+			result = collapseIn(result)
+		}
+	}
+
+	var buf strings.Builder
+	for i, tok := range result {
+		if i > 0 && needsSpace(result[i-1], tok) {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(tok.Value)
+	}
+	return buf.String(), nil
+}
+
+// Fingerprint returns the hash of the normalized src in 16 hex digits
+// SQL statements normalized into the same statement have the same fingerprint
+func Fingerprint(src string) (string, error) {
+	return FingerprintWithOptions(src, &Options{})
+}
+
+// FingerprintWithOptions returns the hash of src normalized by NormalizeWithOptions
+func FingerprintWithOptions(src string, options *Options) (string, error) {
+	normalized, err := NormalizeWithOptions(src, options)
+	if err != nil {
+		return "", errors.Wrap(err, "Normalize failed")
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// isBlockCommentStart returns true if tok starts block comment, which is scanned as an operator "/*"
+func isBlockCommentStart(tok lexer.Token) bool {
+	return tok.Type == lexer.OPERATOR && strings.HasPrefix(tok.Value, "/*")
+}
+
+// commentEndOffset returns the byte offset of the end of the comment starting with tok at offset in src
+// line comment ends at the end of the line, and block comment ends after "*/"
+func commentEndOffset(src string, tok lexer.Token, offset int) int {
+	if isLineComment(tok) {
+		if i := strings.Index(src[offset:], "\n"); i >= 0 {
+			return offset + i
+		}
+		return len(src)
+	}
+	if i := strings.Index(src[offset+len("/*"):], "*/"); i >= 0 {
+		return offset + len("/*") + i + len("*/")
+	}
+	return len(src)
+}
+
+// isLiteral returns true if tok is a value such as 'xxx', 1.5, true or a bind parameter
+func isLiteral(tok lexer.Token) bool {
+	switch tok.Type {
+	case lexer.STRING, lexer.PLACEHOLDER:
+		return true
+	case lexer.IDENT:
+		return isNumber(tok.Value) || strings.EqualFold(tok.Value, "true") || strings.EqualFold(tok.Value, "false")
+	}
+	return false
+}
+
+// isNumber returns true if v is a numeric literal such as 1, -1, .5 and 1.5e-3, which is tokenized as an ident
+func isNumber(v string) bool {
+	v = strings.TrimLeft(v, "+-")
+	if strings.HasPrefix(v, ".") {
+		v = v[1:]
+	}
+	return v != "" && v[0] >= '0' && v[0] <= '9'
+}
+
+// isStringPrefix returns true if tok can be the prefix of string literal such as E of E'\n' and X of X'1F'
+func isStringPrefix(tok lexer.Token) bool {
+	if tok.Type != lexer.IDENT {
+		return false
+	}
+	switch strings.ToUpper(tok.Value) {
+	case "E", "X", "B", "N", "U&":
+		return true
+	}
+	return false
+}
+
+// collapseIn collapses the list of IN (?, ?, ...) at the end of tokens into IN (?)
+func collapseIn(tokens []lexer.Token) []lexer.Token {
+	n := len(tokens)
+	for i := n - 2; i >= 2 && tokens[i].Type == lexer.PLACEHOLDER; i -= 2 {
+		switch tokens[i-1].Type {
+		case lexer.COMMA:
+			continue
+		case lexer.STARTPARENTHESIS:
+			if tokens[i-2].Type == lexer.IN {
+				return append(tokens[:i+1], tokens[n-1])
+			}
+		}
+		break
+	}
+	return tokens
+}
+
+// needsSpace returns true if tok is separated from the previous token by a space
+// no space is put inside parentheses and brackets, before commas, type casts and arguments of functions
+func needsSpace(prev, tok lexer.Token) bool {
+	switch prev.Type {
+	case lexer.STARTPARENTHESIS, lexer.STARTBRACKET:
+		return false
+	}
+	switch tok.Type {
+	case lexer.ENDPARENTHESIS, lexer.ENDBRACKET, lexer.COMMA, lexer.STARTBRACKET:
+		return false
+	case lexer.STARTPARENTHESIS:
+		return prev.Type != lexer.FUNCTION && prev.Type != lexer.TYPE
+	}
+	return !strings.HasPrefix(tok.Value, lexer.TypeCast)
+}
@@ -0,0 +1,119 @@
+package sqlfmt
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "literals and placeholders",
+			src:  `select xxx from xxx where xxx = 'xxx' and xxx > 1.5e-3 and xxx = -1 and xxx = $1 and xxx = true`,
+			want: "SELECT xxx FROM xxx WHERE xxx = ? AND xxx > ? AND xxx = ? AND xxx = ? AND xxx = ?",
+		},
+		{
+			name: "whitespaces and keyword case",
+			src: `
+SELECT
+  count(*)
+  , xxx::int
+FROM xxx
+WHERE xxx = ANY( $1 );`,
+			want: "SELECT COUNT(*), xxx::int FROM xxx WHERE xxx = ANY(?)",
+		},
+		{
+			name: "IN list",
+			src:  `select xxx from xxx where xxx in (1, 2, 3) and xxx not in ($1) and (xxx, xxx) in ((1, 2))`,
+			want: "SELECT xxx FROM xxx WHERE xxx IN (?) AND xxx NOT IN (?) AND (xxx, xxx) IN ((?, ?))",
+		},
+		{
+			name: "prefixed strings",
+			src:  `insert into xxx(xxx, xxx) values (E'\n', X'1F')`,
+			want: "INSERT INTO xxx (xxx, xxx) VALUES (?, ?)",
+		},
+		{
+			name: "comments",
+			src:  "select a -- pick a\nfrom t /* table */ where id = 1 /* unterminated",
+			want: "SELECT a FROM t WHERE id = ?",
+		},
+		{
+			name: "brackets and types",
+			src:  `select xxx[1], array[1, 2], cast(xxx as numeric(10, 2)) from xxx`,
+			want: "SELECT xxx[?], array[?, ?], CAST(xxx AS NUMERIC(?, ?)) FROM xxx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.src)
+			if err != nil {
+				t.Errorf("should be nil, got %v", err)
+			}
+			if tt.want != got {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeWithOptions(t *testing.T) {
+	src := `select xxx from %s where xxx = %d and xxx = $1`
+	want := "SELECT xxx FROM %s WHERE xxx = %d AND xxx = ?"
+
+	got, err := NormalizeWithOptions(src, &Options{PrintfVerbs: true})
+	if err != nil {
+		t.Errorf("should be nil, got %v", err)
+	}
+	if want != got {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{
+			name: "different values",
+			a:    `select xxx from xxx where xxx in (1, 2) and xxx = 'a'`,
+			b:    "SELECT xxx\nFROM xxx\nWHERE xxx IN ($1, $2, $3)\nAND xxx = $4",
+			same: true,
+		},
+		{
+			name: "comments",
+			a:    `select xxx from xxx where xxx = 1`,
+			b:    "-- find xxx\nselect xxx /* hint */ from xxx where xxx = 1",
+			same: true,
+		},
+		{
+			name: "different shapes",
+			a:    `select xxx from xxx where xxx = 1`,
+			b:    `select xxx from xxx where xxx > 1`,
+			same: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Fingerprint(tt.a)
+			if err != nil {
+				t.Errorf("should be nil, got %v", err)
+			}
+			b, err := Fingerprint(tt.b)
+			if err != nil {
+				t.Errorf("should be nil, got %v", err)
+			}
+			if len(a) != 16 {
+				t.Errorf("want 16 hex digits, got %#v", a)
+			}
+			if (a == b) != tt.same {
+				t.Errorf("want same %v, got %#v and %#v", tt.same, a, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// addParserTestingData adds statements joining values of the tokens in the testing data
+func addParserTestingData(f *testing.F) {
+	join := func(tokens []lexer.Token) string {
+		var values []string
+		for _, tok := range tokens {
+			if tok.Type != lexer.EOF {
+				values = append(values, tok.Value)
+			}
+		}
+		return strings.Join(values, " ")
+	}
+	for _, tt := range parseTokensTestingData {
+		f.Add(join(tt.tokenSource))
+	}
+	for _, tt := range markConditionGroupsTestingData {
+		f.Add(join(tt.tokenSource))
+	}
+	for _, src := range unbalancedTestingData {
+		f.Add(src)
+	}
+}
+
+func FuzzParseTokens(f *testing.F) {
+	addParserTestingData(f)
+	f.Fuzz(func(t *testing.T, src string) {
+		tokens, err := lexer.NewTokenizer(src).GetTokens()
+		if err != nil {
+			return
+		}
+		ParseTokens(tokens)
+	})
+}
@@ -0,0 +1,44 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// AlterTable clause
+// each action such as ADD COLUMN, DROP COLUMN is written in its own line
+type AlterTable struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (a *AlterTable) Reindent(buf *bytes.Buffer) error {
+	var hasAction bool
+
+	elements, err := processPunctuation(a.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			// only the first action is found by keyword, the following actions start after comma
+			isActionStart := i > 0 && !hasAction && token.IsAlterActionStart()
+			if isActionStart {
+				hasAction = true
+			}
+			writeAlterTable(buf, token, a.IndentLevel, i == 0, isActionStart)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (a *AlterTable) IncrementIndentLevel(lev int) {
+	a.IndentLevel += lev
+}
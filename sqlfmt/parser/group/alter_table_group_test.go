@@ -0,0 +1,104 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentAlterTableGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.ALTER, Value: "ALTER"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ADD, Value: "ADD"},
+				lexer.Token{Type: lexer.COLUMN, Value: "COLUMN"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.IDENT, Value: "TEXT"},
+				lexer.Token{Type: lexer.COMMA, Value: ","},
+				lexer.Token{Type: lexer.ALTER, Value: "ALTER"},
+				lexer.Token{Type: lexer.COLUMN, Value: "COLUMN"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.DEFAULT, Value: "DEFAULT"},
+			},
+			want: "\nALTER TABLE xxx\n  ADD COLUMN xxx TEXT\n  , ALTER COLUMN xxx DROP DEFAULT",
+		},
+		{
+			name: "IF EXISTS and constraint in multiple actions",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.ALTER, Value: "ALTER"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ADD, Value: "ADD"},
+				lexer.Token{Type: lexer.CONSTRAINT, Value: "CONSTRAINT"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx_fk"},
+				lexer.Token{Type: lexer.FOREIGN, Value: "FOREIGN"},
+				lexer.Token{Type: lexer.KEY, Value: "KEY"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx_id"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+				lexer.Token{Type: lexer.REFERENCES, Value: "REFERENCES"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "id"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+				lexer.Token{Type: lexer.COMMA, Value: ","},
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.COLUMN, Value: "COLUMN"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "yyy"},
+				lexer.Token{Type: lexer.CASCADE, Value: "CASCADE"},
+				lexer.Token{Type: lexer.COMMA, Value: ","},
+				lexer.Token{Type: lexer.RENAME, Value: "RENAME"},
+				lexer.Token{Type: lexer.COLUMN, Value: "COLUMN"},
+				lexer.Token{Type: lexer.IDENT, Value: "zzz"},
+				lexer.Token{Type: lexer.TO, Value: "TO"},
+				lexer.Token{Type: lexer.IDENT, Value: "www"},
+			},
+			want: "\nALTER TABLE IF EXISTS xxx\n  ADD CONSTRAINT xxx_fk FOREIGN KEY (xxx_id) REFERENCES xxx (id)\n  , DROP COLUMN IF EXISTS yyy CASCADE\n  , RENAME COLUMN zzz TO www",
+		},
+		{
+			name: "DROP CONSTRAINT with RESTRICT",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.ALTER, Value: "ALTER"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.CONSTRAINT, Value: "CONSTRAINT"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx_fk"},
+				lexer.Token{Type: lexer.RESTRICT, Value: "RESTRICT"},
+			},
+			want: "\nALTER TABLE xxx\n  DROP CONSTRAINT xxx_fk RESTRICT",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		alterTableGroup := &AlterTable{Element: tt.tokenSource}
+
+		alterTableGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
@@ -7,10 +7,10 @@ import (
 )
 
 // AndGroup is AND clause not AND operator
-// AndGroup is made after new line
+// AndGroup is made in the top level of WHERE, HAVING, ON clause or parenthesis in them
 //// select xxx and xxx  <= this is not AndGroup
-//// select xxx from xxx where xxx
-//// and xxx      <= this is AndGroup
+//// select xxx from xxx where xxx between xxx and xxx  <= this is not AndGroup
+//// select xxx from xxx where xxx and xxx      <= this is AndGroup
 type AndGroup struct {
 	Element     []Reindenter
 	IndentLevel int
@@ -27,7 +27,9 @@ func (a *AndGroup) Reindent(buf *bytes.Buffer) error {
 		if token, ok := el.(lexer.Token); ok {
 			write(buf, token, a.IndentLevel)
 		} else {
-			el.Reindent(buf)
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
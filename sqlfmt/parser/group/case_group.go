@@ -23,7 +23,9 @@ func (c *Case) Reindent(buf *bytes.Buffer) error {
 		if token, ok := v.(lexer.Token); ok {
 			writeCase(buf, token, c.IndentLevel, c.hasCommaBefore)
 		} else {
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
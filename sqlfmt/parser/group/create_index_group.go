@@ -0,0 +1,36 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// CreateIndex clause
+type CreateIndex struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (c *CreateIndex) Reindent(buf *bytes.Buffer) error {
+	elements, err := processPunctuation(c.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeCreateIndex(buf, token, c.IndentLevel, i == 0)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (c *CreateIndex) IncrementIndentLevel(lev int) {
+	c.IndentLevel += lev
+}
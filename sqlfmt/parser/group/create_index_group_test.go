@@ -0,0 +1,75 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentCreateIndexGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.UNIQUE, Value: "UNIQUE"},
+				lexer.Token{Type: lexer.INDEX, Value: "INDEX"},
+				lexer.Token{Type: lexer.CONCURRENTLY, Value: "CONCURRENTLY"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ON, Value: "ON"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+			},
+			want: "\nCREATE UNIQUE INDEX CONCURRENTLY xxx\nON xxx (xxx)",
+		},
+		{
+			name: "IF NOT EXISTS and expression",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.INDEX, Value: "INDEX"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.NOT, Value: "NOT"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ON, Value: "ON"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						&Function{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.FUNCTION, Value: "lower"},
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+			},
+			want: "\nCREATE INDEX IF NOT EXISTS xxx\nON xxx (lower(xxx))",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		createIndexGroup := &CreateIndex{Element: tt.tokenSource}
+
+		createIndexGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
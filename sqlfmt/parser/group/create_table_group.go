@@ -0,0 +1,62 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// CreateTable clause
+// each column definition or table constraint in the first parenthesis is written in its own line
+type CreateTable struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (c *CreateTable) Reindent(buf *bytes.Buffer) error {
+	var hasTableElements bool
+
+	elements, err := processPunctuation(c.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeDDL(buf, token, c.IndentLevel, i == 0)
+		} else if paren, ok := el.(*Parenthesis); ok && !hasTableElements {
+			if err := c.reindentTableElements(buf, paren); err != nil {
+				return err
+			}
+			hasTableElements = true
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reindentTableElements writes column definitions and table constraints surrounded with parenthesis
+func (c *CreateTable) reindentTableElements(buf *bytes.Buffer, paren *Parenthesis) error {
+	elements, err := processPunctuation(paren.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeTableElement(buf, token, c.IndentLevel, i == 1)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (c *CreateTable) IncrementIndentLevel(lev int) {
+	c.IndentLevel += lev
+}
@@ -0,0 +1,127 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentCreateTableGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "id"},
+						lexer.Token{Type: lexer.IDENT, Value: "INTEGER"},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.PRIMARY, Value: "PRIMARY"},
+						lexer.Token{Type: lexer.KEY, Value: "KEY"},
+						&Parenthesis{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "id"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+			},
+			want: "\nCREATE TABLE xxx (\n  id INTEGER\n  , PRIMARY KEY (id)\n)",
+		},
+		{
+			name: "IF NOT EXISTS and constraints",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.NOT, Value: "NOT"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "id"},
+						lexer.Token{Type: lexer.IDENT, Value: "INTEGER"},
+						lexer.Token{Type: lexer.NOT, Value: "NOT"},
+						lexer.Token{Type: lexer.NULL, Value: "NULL"},
+						lexer.Token{Type: lexer.DEFAULT, Value: "DEFAULT"},
+						lexer.Token{Type: lexer.IDENT, Value: "0"},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx_id"},
+						lexer.Token{Type: lexer.IDENT, Value: "INTEGER"},
+						lexer.Token{Type: lexer.CONSTRAINT, Value: "CONSTRAINT"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx_fk"},
+						lexer.Token{Type: lexer.REFERENCES, Value: "REFERENCES"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						&Parenthesis{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "id"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.ON, Value: "ON"},
+						lexer.Token{Type: lexer.DELETE, Value: "DELETE"},
+						lexer.Token{Type: lexer.CASCADE, Value: "CASCADE"},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.CONSTRAINT, Value: "CONSTRAINT"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx_pk"},
+						lexer.Token{Type: lexer.PRIMARY, Value: "PRIMARY"},
+						lexer.Token{Type: lexer.KEY, Value: "KEY"},
+						&Parenthesis{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "id"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.UNIQUE, Value: "UNIQUE"},
+						&Parenthesis{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "xxx_id"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.CHECK, Value: "CHECK"},
+						&Parenthesis{
+							Element: []Reindenter{
+								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+								lexer.Token{Type: lexer.IDENT, Value: "id"},
+								lexer.Token{Type: lexer.OPERATOR, Value: ">"},
+								lexer.Token{Type: lexer.IDENT, Value: "0"},
+								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+							},
+						},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+			},
+			want: "\nCREATE TABLE IF NOT EXISTS xxx (\n  id INTEGER NOT NULL DEFAULT 0\n  , xxx_id INTEGER CONSTRAINT xxx_fk REFERENCES xxx (id) ON DELETE CASCADE\n  , CONSTRAINT xxx_pk PRIMARY KEY (id)\n  , UNIQUE (xxx_id)\n  , CHECK (id > 0)\n)",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		createTableGroup := &CreateTable{Element: tt.tokenSource}
+
+		createTableGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
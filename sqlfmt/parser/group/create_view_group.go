@@ -0,0 +1,37 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// CreateView clause
+// the query after AS is not included in this group
+type CreateView struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (c *CreateView) Reindent(buf *bytes.Buffer) error {
+	elements, err := processPunctuation(c.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeDDL(buf, token, c.IndentLevel, i == 0)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (c *CreateView) IncrementIndentLevel(lev int) {
+	c.IndentLevel += lev
+}
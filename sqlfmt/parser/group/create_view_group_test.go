@@ -0,0 +1,57 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentCreateViewGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.MATERIALIZED, Value: "MATERIALIZED"},
+				lexer.Token{Type: lexer.VIEW, Value: "VIEW"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.AS, Value: "AS"},
+			},
+			want: "\nCREATE MATERIALIZED VIEW xxx AS",
+		},
+		{
+			name: "column list",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+				lexer.Token{Type: lexer.VIEW, Value: "VIEW"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.COMMA, Value: ","},
+						lexer.Token{Type: lexer.IDENT, Value: "yyy"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+				lexer.Token{Type: lexer.AS, Value: "AS"},
+			},
+			want: "\nCREATE VIEW xxx (xxx, yyy) AS",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		createViewGroup := &CreateView{Element: tt.tokenSource}
+
+		createViewGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
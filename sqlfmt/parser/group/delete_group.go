@@ -22,7 +22,9 @@ func (d *Delete) Reindent(buf *bytes.Buffer) error {
 		if token, ok := el.(lexer.Token); ok {
 			write(buf, token, d.IndentLevel)
 		} else {
-			el.Reindent(buf)
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
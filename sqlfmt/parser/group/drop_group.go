@@ -0,0 +1,36 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// Drop clause
+type Drop struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (d *Drop) Reindent(buf *bytes.Buffer) error {
+	elements, err := processPunctuation(d.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeDDL(buf, token, d.IndentLevel, i == 0)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (d *Drop) IncrementIndentLevel(lev int) {
+	d.IndentLevel += lev
+}
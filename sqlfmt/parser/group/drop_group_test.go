@@ -0,0 +1,63 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentDropGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.TABLE, Value: "TABLE"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.COMMA, Value: ","},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.CASCADE, Value: "CASCADE"},
+			},
+			want: "\nDROP TABLE IF EXISTS xxx, xxx CASCADE",
+		},
+		{
+			name: "IF EXISTS",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.INDEX, Value: "INDEX"},
+				lexer.Token{Type: lexer.CONCURRENTLY, Value: "CONCURRENTLY"},
+				lexer.Token{Type: lexer.IF, Value: "IF"},
+				lexer.Token{Type: lexer.EXISTS, Value: "EXISTS"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+			},
+			want: "\nDROP INDEX CONCURRENTLY IF EXISTS xxx",
+		},
+		{
+			name: "RESTRICT",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.DROP, Value: "DROP"},
+				lexer.Token{Type: lexer.VIEW, Value: "VIEW"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.RESTRICT, Value: "RESTRICT"},
+			},
+			want: "\nDROP VIEW xxx RESTRICT",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		dropGroup := &Drop{Element: tt.tokenSource}
+
+		dropGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
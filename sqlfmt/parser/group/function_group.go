@@ -8,10 +8,11 @@ import (
 
 // Function clause
 type Function struct {
-	Element      []Reindenter
-	IndentLevel  int
-	InColumnArea bool
-	ColumnCount  int
+	Element        []Reindenter
+	IndentLevel    int
+	InColumnArea   bool
+	ColumnCount    int
+	hasStartBefore bool
 }
 
 // Reindent reindents its elements
@@ -30,9 +31,15 @@ func (f *Function) Reindent(buf *bytes.Buffer) error {
 					prev = preToken
 				}
 			}
-			writeFunction(buf, token, prev, f.IndentLevel, f.ColumnCount, f.InColumnArea)
+			writeFunction(buf, token, prev, f.IndentLevel, f.ColumnCount, f.InColumnArea, f.hasStartBefore)
 		} else {
-			el.Reindent(buf)
+			// nested function such as SUM(AVG(xxx)) is written right after "("
+			if fn, ok := el.(*Function); ok && isStartParenthesis(elements[i-1]) {
+				fn.hasStartBefore = true
+			}
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
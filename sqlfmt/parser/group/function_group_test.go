@@ -23,6 +23,23 @@ func TestReindentFunctionGroup(t *testing.T) {
 			},
 			want: " SUM(xxx)",
 		},
+		{
+			name: "nested function",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.FUNCTION, Value: "SUM"},
+				lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+				&Function{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.FUNCTION, Value: "lower"},
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+				lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			},
+			want: " SUM(lower(xxx))",
+		},
 	}
 	for _, tt := range tests {
 		buf := &bytes.Buffer{}
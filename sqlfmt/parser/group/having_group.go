@@ -22,7 +22,9 @@ func (h *Having) Reindent(buf *bytes.Buffer) error {
 		if token, ok := el.(lexer.Token); ok {
 			write(buf, token, h.IndentLevel)
 		} else {
-			el.Reindent(buf)
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -22,7 +22,9 @@ func (insert *Insert) Reindent(buf *bytes.Buffer) error {
 		if token, ok := el.(lexer.Token); ok {
 			write(buf, token, insert.IndentLevel)
 		} else {
-			el.Reindent(buf)
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -22,7 +22,9 @@ func (j *Join) Reindent(buf *bytes.Buffer) error {
 		if token, ok := v.(lexer.Token); ok {
 			writeJoin(buf, token, j.IndentLevel, i == 0)
 		} else {
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -28,7 +28,9 @@ func (o *OrderBy) Reindent(buf *bytes.Buffer) error {
 				return err
 			}
 		case Reindenter:
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
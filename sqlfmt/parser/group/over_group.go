@@ -0,0 +1,32 @@
+package group
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// Over group is window specification of window function such as OVER (PARTITION BY xxx ORDER BY xxx)
+type Over struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (o *Over) Reindent(buf *bytes.Buffer) error {
+	elements, err := processPunctuation(o.Element)
+	if err != nil {
+		return err
+	}
+	if token, ok := elements[0].(lexer.Token); ok {
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+	// window specification is indented deeper than the column that window function is in
+	return reindentWindowSpec(buf, elements[1:], o.IndentLevel+1)
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (o *Over) IncrementIndentLevel(lev int) {
+	o.IndentLevel += lev
+}
@@ -0,0 +1,54 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentOverGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.OVER, Value: "OVER"},
+				lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+				lexer.Token{Type: lexer.PARTITION, Value: "PARTITION"},
+				lexer.Token{Type: lexer.BY, Value: "BY"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
+				lexer.Token{Type: lexer.BY, Value: "BY"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			},
+			want: " OVER (\n    PARTITION BY xxx\n    ORDER BY xxx\n  )",
+		},
+		{
+			name: "single clause",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.OVER, Value: "OVER"},
+				lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+				lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
+				lexer.Token{Type: lexer.BY, Value: "BY"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			},
+			want: " OVER (ORDER BY xxx)",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		overGroup := &Over{Element: tt.tokenSource}
+
+		overGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
@@ -22,18 +22,41 @@ func (p *Parenthesis) Reindent(buf *bytes.Buffer) error {
 	if err != nil {
 		return err
 	}
+	if hasConditionGroup(elements) {
+		return p.reindentConditions(buf, elements)
+	}
 	for i, el := range elements {
 		if token, ok := el.(lexer.Token); ok {
 			hasStartBefore = (i == 1)
 			writeParenthesis(buf, token, p.IndentLevel, p.ColumnCount, p.InColumnArea, hasStartBefore)
 		} else {
-			el.Reindent(buf)
+			if fn, ok := el.(*Function); ok && i == 1 {
+				fn.hasStartBefore = true
+			}
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// reindentConditions writes conditions such as (xxx OR xxx) in their own lines
+// tokens in parenthesis have already been indented deeper than parenthesis by parser
+func (p *Parenthesis) reindentConditions(buf *bytes.Buffer, elements []Reindenter) error {
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeConditionParenthesis(buf, token, p.IndentLevel, i == 1)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // IncrementIndentLevel indents by its specified indent level
 func (p *Parenthesis) IncrementIndentLevel(lev int) {
 	p.IndentLevel += lev
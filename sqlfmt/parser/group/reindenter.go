@@ -33,7 +33,7 @@ func write(buf *bytes.Buffer, token lexer.Token, indent int) {
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	case token.Type == lexer.DO:
 		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, token.Value, WhiteSpace))
-	case strings.HasPrefix(token.Value, "::"):
+	case isTypeCast(token):
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	case token.Type == lexer.WITH:
 		buf.WriteString(fmt.Sprintf("%s%s", NewLine, token.Value))
@@ -58,7 +58,7 @@ func writeWithComma(buf *bytes.Buffer, v interface{}, indent int) error {
 		str = strings.TrimRight(str, " ")
 		if columnCount == 0 {
 			buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, str))
-		} else if strings.HasPrefix(token.Value, "::") {
+		} else if isTypeCast(token) {
 			buf.WriteString(fmt.Sprintf("%s", str))
 		} else {
 			buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, str))
@@ -107,7 +107,7 @@ func writeCase(buf *bytes.Buffer, token lexer.Token, indent int, hasCommaBefore
 		case lexer.COMMA:
 			buf.WriteString(fmt.Sprintf("%s", token.Value))
 		default:
-			if strings.HasPrefix(token.Value, "::") {
+			if isTypeCast(token) {
 				buf.WriteString(fmt.Sprintf("%s", token.Value))
 			} else {
 				buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
@@ -122,7 +122,7 @@ func writeCase(buf *bytes.Buffer, token lexer.Token, indent int, hasCommaBefore
 		case lexer.COMMA:
 			buf.WriteString(fmt.Sprintf("%s", token.Value))
 		default:
-			if strings.HasPrefix(token.Value, "::") {
+			if isTypeCast(token) {
 				buf.WriteString(fmt.Sprintf("%s", token.Value))
 			} else {
 				buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
@@ -137,25 +137,31 @@ func writeJoin(buf *bytes.Buffer, token lexer.Token, indent int, isFirst bool) {
 		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
 	case token.Type == lexer.ON || token.Type == lexer.USING:
 		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
-	case strings.HasPrefix(token.Value, "::"):
+	case isTypeCast(token):
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	default:
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	}
 }
 
-func writeFunction(buf *bytes.Buffer, token, prev lexer.Token, indent, columnCount int, inColumnArea bool) {
+func writeFunction(buf *bytes.Buffer, token, prev lexer.Token, indent, columnCount int, inColumnArea, hasStartBefore bool) {
 	switch {
+	// "(" is written with whitespace unless it starts arguments, such as xxx IN ($1, $2) in function
+	case token.Type == lexer.STARTPARENTHESIS && prev.Type != lexer.FUNCTION && prev.Type != lexer.STARTPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	case prev.Type == lexer.STARTPARENTHESIS || token.Type == lexer.STARTPARENTHESIS || token.Type == lexer.ENDPARENTHESIS:
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	// type cast with type modifiers such as ::numeric(10, 2) is scanned as a function
+	case isTypeCast(token):
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case token.Type == lexer.FUNCTION && hasStartBefore:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	case token.Type == lexer.FUNCTION && columnCount == 0 && inColumnArea:
 		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
 	case token.Type == lexer.FUNCTION:
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	case token.Type == lexer.COMMA:
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
-	case strings.HasPrefix(token.Value, "::"):
-		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	default:
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	}
@@ -173,7 +179,24 @@ func writeParenthesis(buf *bytes.Buffer, token lexer.Token, indent, columnCount
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	case hasStartBefore:
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
-	case strings.HasPrefix(token.Value, "::"):
+	case isTypeCast(token):
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	default:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+}
+
+func writeConditionParenthesis(buf *bytes.Buffer, token lexer.Token, indent int, isFirst bool) {
+	switch {
+	case token.Type == lexer.STARTPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	case token.Type == lexer.ENDPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent-1), token.Value))
+	case isFirst:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	case token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case isTypeCast(token):
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	default:
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
@@ -190,13 +213,18 @@ func writeSubquery(buf *bytes.Buffer, token lexer.Token, indent, columnCount int
 		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
 	case token.Type == lexer.ENDPARENTHESIS:
 		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent-1), token.Value))
-	case strings.HasPrefix(token.Value, "::"):
+	case isTypeCast(token):
 		buf.WriteString(fmt.Sprintf("%s", token.Value))
 	default:
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	}
 }
 
+// isTypeCast returns true if token is type cast such as ::int, which is scanned with the type name
+func isTypeCast(token lexer.Token) bool {
+	return strings.HasPrefix(token.Value, lexer.TypeCast)
+}
+
 func writeTypeCast(buf *bytes.Buffer, token lexer.Token) {
 	switch token.Type {
 	case lexer.TYPE:
@@ -218,3 +246,83 @@ func writeLock(buf *bytes.Buffer, token lexer.Token) {
 		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
 	}
 }
+
+func writeDDL(buf *bytes.Buffer, token lexer.Token, indent int, isFirst bool) {
+	switch {
+	case isFirst:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	case token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case isTypeCast(token):
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	default:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+}
+
+func writeTableElement(buf *bytes.Buffer, token lexer.Token, indent int, isFirst bool) {
+	switch {
+	case isFirst:
+		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
+	case token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
+	case token.Type == lexer.STARTPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	case token.Type == lexer.ENDPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	case isTypeCast(token):
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	default:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+}
+
+func writeCreateIndex(buf *bytes.Buffer, token lexer.Token, indent int, isFirst bool) {
+	switch {
+	case token.Type == lexer.ON:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	default:
+		writeDDL(buf, token, indent, isFirst)
+	}
+}
+
+func writeAlterTable(buf *bytes.Buffer, token lexer.Token, indent int, isFirst, isActionStart bool) {
+	switch {
+	case isActionStart || token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
+	default:
+		writeDDL(buf, token, indent, isFirst)
+	}
+}
+
+func writeWindowSpec(buf *bytes.Buffer, token lexer.Token, indent int, isFirst, isMultiLine bool) {
+	switch {
+	case token.Type == lexer.STARTPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	case token.Type == lexer.ENDPARENTHESIS && isMultiLine:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	case token.Type == lexer.ENDPARENTHESIS:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case isMultiLine && (isFirst || token.IsWindowSpecClauseStart()):
+		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
+	case isFirst:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	case isTypeCast(token):
+		buf.WriteString(fmt.Sprintf("%s", token.Value))
+	default:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+}
+
+func writeWindow(buf *bytes.Buffer, token lexer.Token, indent int, isFirstWindow bool) {
+	switch {
+	case token.Type == lexer.WINDOW:
+		buf.WriteString(fmt.Sprintf("%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), token.Value))
+	case isFirstWindow || token.Type == lexer.COMMA:
+		buf.WriteString(fmt.Sprintf("%s%s%s%s", NewLine, strings.Repeat(DoubleWhiteSpace, indent), DoubleWhiteSpace, token.Value))
+	default:
+		buf.WriteString(fmt.Sprintf("%s%s", WhiteSpace, token.Value))
+	}
+}
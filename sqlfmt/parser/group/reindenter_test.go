@@ -0,0 +1,89 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+	"github.com/pkg/errors"
+)
+
+// errReindenter is a Reindenter which always fails to reindent
+type errReindenter struct{}
+
+func (errReindenter) Reindent(buf *bytes.Buffer) error {
+	return errors.New("can not reindent")
+}
+
+func (errReindenter) IncrementIndentLevel(lev int) {}
+
+func TestReindentNestedError(t *testing.T) {
+	elements := func(typ lexer.TokenType, value string) []Reindenter {
+		return []Reindenter{
+			lexer.Token{Type: typ, Value: value},
+			errReindenter{},
+		}
+	}
+	tests := []struct {
+		name  string
+		group Reindenter
+	}{
+		{name: "select", group: &Select{Element: elements(lexer.SELECT, "SELECT")}},
+		{name: "from", group: &From{Element: elements(lexer.FROM, "FROM")}},
+		{name: "where", group: &Where{Element: elements(lexer.WHERE, "WHERE")}},
+		{name: "and", group: &AndGroup{Element: elements(lexer.AND, "AND")}},
+		{name: "or", group: &OrGroup{Element: elements(lexer.OR, "OR")}},
+		{name: "join", group: &Join{Element: elements(lexer.JOIN, "JOIN")}},
+		{name: "group by", group: &GroupBy{Element: elements(lexer.GROUP, "GROUP BY")}},
+		{name: "having", group: &Having{Element: elements(lexer.HAVING, "HAVING")}},
+		{name: "order by", group: &OrderBy{Element: elements(lexer.ORDER, "ORDER BY")}},
+		{name: "limit", group: &LimitClause{Element: elements(lexer.LIMIT, "LIMIT")}},
+		{name: "case", group: &Case{Element: elements(lexer.CASE, "CASE")}},
+		{name: "function", group: &Function{Element: elements(lexer.FUNCTION, "SUM")}},
+		{name: "parenthesis", group: &Parenthesis{Element: elements(lexer.STARTPARENTHESIS, "(")}},
+		{name: "subquery", group: &Subquery{Element: elements(lexer.STARTPARENTHESIS, "(")}},
+		{name: "insert", group: &Insert{Element: elements(lexer.INSERT, "INSERT")}},
+		{name: "values", group: &Values{Element: elements(lexer.VALUES, "VALUES")}},
+		{name: "update", group: &Update{Element: elements(lexer.UPDATE, "UPDATE")}},
+		{name: "set", group: &Set{Element: elements(lexer.SET, "SET")}},
+		{name: "delete", group: &Delete{Element: elements(lexer.DELETE, "DELETE")}},
+		{name: "returning", group: &Returning{Element: elements(lexer.RETURNING, "RETURNING")}},
+		{name: "with", group: &With{Element: elements(lexer.WITH, "WITH")}},
+		{name: "over", group: &Over{Element: elements(lexer.OVER, "OVER")}},
+		{name: "window", group: &Window{Element: elements(lexer.WINDOW, "WINDOW")}},
+		{name: "lock", group: &Lock{Element: elements(lexer.FOR, "FOR")}},
+		{name: "tie clause", group: &TieClause{Element: elements(lexer.UNION, "UNION")}},
+		{name: "create table", group: &CreateTable{Element: elements(lexer.CREATE, "CREATE")}},
+		{name: "create index", group: &CreateIndex{Element: elements(lexer.CREATE, "CREATE")}},
+		{name: "create view", group: &CreateView{Element: elements(lexer.CREATE, "CREATE")}},
+		{name: "alter table", group: &AlterTable{Element: elements(lexer.ALTER, "ALTER")}},
+		{name: "drop", group: &Drop{Element: elements(lexer.DROP, "DROP")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.group.Reindent(&bytes.Buffer{}); err == nil {
+				t.Errorf("error of the nested element should be returned")
+			}
+		})
+	}
+}
+
+func TestIsTypeCast(t *testing.T) {
+	tests := []struct {
+		name  string
+		token lexer.Token
+		want  bool
+	}{
+		{name: "type cast", token: lexer.Token{Type: lexer.IDENT, Value: "::int"}, want: true},
+		{name: "type cast with arguments", token: lexer.Token{Type: lexer.FUNCTION, Value: "::numeric"}, want: true},
+		{name: "placeholder", token: lexer.Token{Type: lexer.PLACEHOLDER, Value: ":xxx"}, want: false},
+		{name: "ident", token: lexer.Token{Type: lexer.IDENT, Value: "xxx"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTypeCast(tt.token); got != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
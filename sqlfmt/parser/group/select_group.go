@@ -36,31 +36,43 @@ func (s *Select) Reindent(buf *bytes.Buffer) error {
 					v.hasCommaBefore = true
 				}
 			}
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 			// Case group in Select clause must be in column area
 			columnCount++
 		case *Parenthesis:
 			v.InColumnArea = true
 			v.ColumnCount = columnCount
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 			columnCount++
 		case *Subquery:
 			if token, ok := elements[i-1].(lexer.Token); ok {
 				if token.Type == lexer.EXISTS {
-					v.Reindent(buf)
+					if err := v.Reindent(buf); err != nil {
+						return err
+					}
 					continue
 				}
 			}
 			v.InColumnArea = true
 			v.ColumnCount = columnCount
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 		case *Function:
 			v.InColumnArea = true
 			v.ColumnCount = columnCount
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 			columnCount++
 		case Reindenter:
-			v.Reindent(buf)
+			if err := v.Reindent(buf); err != nil {
+				return err
+			}
 			columnCount++
 		default:
 			return fmt.Errorf("can not reindent %#v", v)
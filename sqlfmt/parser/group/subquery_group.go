@@ -26,9 +26,13 @@ func (s *Subquery) Reindent(buf *bytes.Buffer) error {
 		} else {
 			if s.InColumnArea {
 				el.IncrementIndentLevel(1)
-				el.Reindent(buf)
+				if err := el.Reindent(buf); err != nil {
+					return err
+				}
 			} else {
-				el.Reindent(buf)
+				if err := el.Reindent(buf); err != nil {
+					return err
+				}
 			}
 		}
 	}
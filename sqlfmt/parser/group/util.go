@@ -3,7 +3,6 @@ package group
 import (
 	"bytes"
 	"fmt"
-	"strings"
 
 	"github.com/pkg/errors"
 
@@ -43,7 +42,7 @@ func separate(rs []Reindenter) []interface{} {
 				result = append(result, token)
 				buf.Reset()
 				count = 0
-			case strings.HasPrefix(token.Value, "::"):
+			case isTypeCast(token):
 				buf.WriteString(token.Value)
 			default:
 				if count == 0 {
@@ -62,6 +61,23 @@ func separate(rs []Reindenter) []interface{} {
 	return result
 }
 
+// isStartParenthesis returns true if r is "(" token
+func isStartParenthesis(r Reindenter) bool {
+	token, ok := r.(lexer.Token)
+	return ok && token.Type == lexer.STARTPARENTHESIS
+}
+
+// hasConditionGroup returns true if AndGroup or OrGroup is in rs
+func hasConditionGroup(rs []Reindenter) bool {
+	for _, r := range rs {
+		switch r.(type) {
+		case *AndGroup, *OrGroup:
+			return true
+		}
+	}
+	return false
+}
+
 // process bracket, singlequote and brace
 // TODO: more elegant
 func processPunctuation(rs []Reindenter) ([]Reindenter, error) {
@@ -133,3 +149,106 @@ func extractSurroundingArea(rs []Reindenter) (string, int, error) {
 	}
 	return result, skipRange, nil
 }
+
+// reindentWindowSpec writes window specification surrounded with parenthesis such as (PARTITION BY xxx ORDER BY xxx)
+// if it has multiple clauses, each clause is written in its own line
+func reindentWindowSpec(buf *bytes.Buffer, elements []Reindenter, indent int) error {
+	isMultiLine := countWindowSpecClauses(elements) > 1
+
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeWindowSpec(buf, token, indent, i == 1, isMultiLine)
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// countWindowSpecClauses counts existing window name, PARTITION BY, ORDER BY and frame clause in window specification
+func countWindowSpecClauses(elements []Reindenter) int {
+	var count int
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			switch {
+			case token.IsWindowSpecClauseStart():
+				count++
+			// existing window name such as (xxx ORDER BY xxx)
+			case i == 1 && token.Type == lexer.IDENT:
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Elements returns elements of clause group r, or nil if r is a token
+func Elements(r Reindenter) []Reindenter {
+	switch v := r.(type) {
+	case *AlterTable:
+		return v.Element
+	case *AndGroup:
+		return v.Element
+	case *Case:
+		return v.Element
+	case *CreateIndex:
+		return v.Element
+	case *CreateTable:
+		return v.Element
+	case *CreateView:
+		return v.Element
+	case *Delete:
+		return v.Element
+	case *Drop:
+		return v.Element
+	case *From:
+		return v.Element
+	case *Function:
+		return v.Element
+	case *GroupBy:
+		return v.Element
+	case *Having:
+		return v.Element
+	case *Insert:
+		return v.Element
+	case *Join:
+		return v.Element
+	case *LimitClause:
+		return v.Element
+	case *Lock:
+		return v.Element
+	case *OrGroup:
+		return v.Element
+	case *OrderBy:
+		return v.Element
+	case *Over:
+		return v.Element
+	case *Parenthesis:
+		return v.Element
+	case *Returning:
+		return v.Element
+	case *Select:
+		return v.Element
+	case *Set:
+		return v.Element
+	case *Subquery:
+		return v.Element
+	case *TieClause:
+		return v.Element
+	case *TypeCast:
+		return v.Element
+	case *Update:
+		return v.Element
+	case *Values:
+		return v.Element
+	case *Where:
+		return v.Element
+	case *Window:
+		return v.Element
+	case *With:
+		return v.Element
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package group
+
+import (
+	"bytes"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+// Window clause
+// each window definition is written in its own line
+type Window struct {
+	Element     []Reindenter
+	IndentLevel int
+}
+
+// Reindent reindents its elements
+func (w *Window) Reindent(buf *bytes.Buffer) error {
+	elements, err := processPunctuation(w.Element)
+	if err != nil {
+		return err
+	}
+	for i, el := range elements {
+		if token, ok := el.(lexer.Token); ok {
+			writeWindow(buf, token, w.IndentLevel, i == 1)
+		} else if paren, ok := el.(*Parenthesis); ok {
+			spec, err := processPunctuation(paren.Element)
+			if err != nil {
+				return err
+			}
+			if err := reindentWindowSpec(buf, spec, w.IndentLevel+1); err != nil {
+				return err
+			}
+		} else {
+			if err := el.Reindent(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IncrementIndentLevel increments by its specified indent level
+func (w *Window) IncrementIndentLevel(lev int) {
+	w.IndentLevel += lev
+}
@@ -0,0 +1,60 @@
+package group
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+)
+
+func TestReindentWindowGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenSource []Reindenter
+		want        string
+	}{
+		{
+			name: "normal case",
+			tokenSource: []Reindenter{
+				lexer.Token{Type: lexer.WINDOW, Value: "WINDOW"},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.AS, Value: "AS"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.PARTITION, Value: "PARTITION"},
+						lexer.Token{Type: lexer.BY, Value: "BY"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
+						lexer.Token{Type: lexer.BY, Value: "BY"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+				lexer.Token{Type: lexer.COMMA, Value: ","},
+				lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				lexer.Token{Type: lexer.AS, Value: "AS"},
+				&Parenthesis{
+					Element: []Reindenter{
+						lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+						lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
+						lexer.Token{Type: lexer.BY, Value: "BY"},
+						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+						lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+					},
+				},
+			},
+			want: "\nWINDOW\n  xxx AS (\n    PARTITION BY xxx\n    ORDER BY xxx\n  )\n  , xxx AS (ORDER BY xxx)",
+		},
+	}
+	for _, tt := range tests {
+		buf := &bytes.Buffer{}
+		windowGroup := &Window{Element: tt.tokenSource}
+
+		windowGroup.Reindent(buf)
+		got := buf.String()
+		if tt.want != got {
+			t.Errorf("want%#v, got %#v", tt.want, got)
+		}
+	}
+}
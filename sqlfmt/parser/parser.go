@@ -18,21 +18,32 @@ type parser struct {
 // ParseTokens parses Tokens, creating slice of Reindenter
 // each Reindenter is group of SQL Clause such as SelectGroup, FromGroup ...etc
 func ParseTokens(tokens []lexer.Token) ([]group.Reindenter, error) {
-	if !isSQL(tokens[0].Type) {
+	if len(tokens) == 0 || !isSQL(tokens[0].Type) {
 		return nil, errors.New("can not parse no sql statement")
 	}
 
+	if !isBalanced(tokens) {
+		return nil, errors.New("can not parse statement with unbalanced parenthesis, bracket or brace")
+	}
+
 	var (
 		offset int
 		result []group.Reindenter
 	)
+	tokens = markConditionGroups(tokens)
 
 	for {
+		if offset >= len(tokens) {
+			return nil, errors.New("ParseTokens failed: EOF is not found")
+		}
 		if tokens[offset].Type == lexer.EOF {
 			break
 		}
 
 		r := NewRetriever(tokens[offset:])
+		if r == nil {
+			return nil, errors.Errorf("ParseTokens failed: can not parse from %s", tokens[offset].Value)
+		}
 		element, endIdx, err := r.Retrieve()
 		if err != nil {
 			return nil, errors.Wrap(err, "ParseTokens failed")
@@ -47,5 +58,77 @@ func ParseTokens(tokens []lexer.Token) ([]group.Reindenter, error) {
 }
 
 func isSQL(ttype lexer.TokenType) bool {
-	return ttype == lexer.SELECT || ttype == lexer.UPDATE || ttype == lexer.DELETE || ttype == lexer.INSERT || ttype == lexer.LOCK || ttype == lexer.WITH
+	return ttype == lexer.SELECT || ttype == lexer.UPDATE || ttype == lexer.DELETE || ttype == lexer.INSERT || ttype == lexer.LOCK || ttype == lexer.WITH || ttype == lexer.CREATE || ttype == lexer.ALTER || ttype == lexer.DROP
+}
+
+// isBalanced returns true if every parenthesis, bracket and brace is closed in the right order
+func isBalanced(tokens []lexer.Token) bool {
+	pairs := map[lexer.TokenType]lexer.TokenType{
+		lexer.ENDPARENTHESIS: lexer.STARTPARENTHESIS,
+		lexer.ENDBRACKET:     lexer.STARTBRACKET,
+		lexer.ENDBRACE:       lexer.STARTBRACE,
+	}
+	var stack []lexer.TokenType
+	for _, tok := range tokens {
+		switch tok.Type {
+		case lexer.STARTPARENTHESIS, lexer.STARTBRACKET, lexer.STARTBRACE:
+			stack = append(stack, tok.Type)
+		case lexer.ENDPARENTHESIS, lexer.ENDBRACKET, lexer.ENDBRACE:
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[tok.Type] {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return len(stack) == 0
+}
+
+// markConditionGroups replaces AND, OR in the top level of WHERE, HAVING and ON clause with ANDGROUP, ORGROUP
+// so that each condition is written in its own line regardless of new lines in the source
+// AND of BETWEEN xxx AND xxx, and AND, OR in functions or CASE are not replaced
+// ON of referential actions such as ON DELETE CASCADE of foreign keys does not start condition
+func markConditionGroups(tokens []lexer.Token) []lexer.Token {
+	type frame struct {
+		isCondition bool
+		hasBetween  bool
+	}
+	var (
+		result = make([]lexer.Token, 0, len(tokens))
+		frames = []*frame{{}}
+		prev   lexer.Token
+	)
+
+	for i, tok := range tokens {
+		current := frames[len(frames)-1]
+
+		switch {
+		case tok.Type == lexer.STARTPARENTHESIS:
+			isCondition := current.isCondition && prev.Type != lexer.FUNCTION && prev.Type != lexer.TYPE && prev.Type != lexer.OVER
+			frames = append(frames, &frame{isCondition: isCondition})
+		case tok.Type == lexer.CASE:
+			frames = append(frames, &frame{})
+		case (tok.Type == lexer.ENDPARENTHESIS || tok.Type == lexer.END) && len(frames) > 1:
+			frames = frames[:len(frames)-1]
+		case tok.IsConditionClauseStart() && !isReferentialAction(tokens, i):
+			current.isCondition = true
+		case tok.IsOtherClauseStart():
+			current.isCondition = false
+		case tok.Type == lexer.BETWEEN:
+			current.hasBetween = true
+		case tok.Type == lexer.AND && current.hasBetween:
+			current.hasBetween = false
+		case tok.Type == lexer.AND && current.isCondition:
+			tok = lexer.Token{Type: lexer.ANDGROUP, Value: tok.Value}
+		case tok.Type == lexer.OR && current.isCondition:
+			tok = lexer.Token{Type: lexer.ORGROUP, Value: tok.Value}
+		}
+		result = append(result, tok)
+		prev = tok
+	}
+	return result
+}
+
+// isReferentialAction returns true if tokens[i] is ON of ON DELETE or ON UPDATE in foreign key constraint
+func isReferentialAction(tokens []lexer.Token, i int) bool {
+	return tokens[i].Type == lexer.ON && i+1 < len(tokens) && (tokens[i+1].Type == lexer.DELETE || tokens[i+1].Type == lexer.UPDATE)
 }
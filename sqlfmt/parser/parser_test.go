@@ -9,261 +9,428 @@ import (
 )
 
 func TestParseTokens(t *testing.T) {
-	testingData := []lexer.Token{
-		{Type: lexer.SELECT, Value: "SELECT"},
-		{Type: lexer.IDENT, Value: "name"},
-		{Type: lexer.COMMA, Value: ","},
-		{Type: lexer.IDENT, Value: "age"},
-		{Type: lexer.COMMA, Value: ","},
+	for _, tt := range parseTokensTestingData {
+		got, err := ParseTokens(tt.tokenSource)
+		if err != nil {
+			t.Errorf("ERROR: %#v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+		}
+	}
+}
 
-		{Type: lexer.FUNCTION, Value: "SUM"},
-		{Type: lexer.STARTPARENTHESIS, Value: "("},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ENDPARENTHESIS, Value: ")"},
+var parseTokensTestingData = []struct {
+	name        string
+	tokenSource []lexer.Token
+	want        []group.Reindenter
+}{
+	{
+		name: "normal test case 1",
+		tokenSource: []lexer.Token{
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "name"},
+			{Type: lexer.COMMA, Value: ","},
+			{Type: lexer.IDENT, Value: "age"},
+			{Type: lexer.COMMA, Value: ","},
 
-		{Type: lexer.STARTPARENTHESIS, Value: "("},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.FUNCTION, Value: "SUM"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
 
-		{Type: lexer.TYPE, Value: "TEXT"},
-		{Type: lexer.STARTPARENTHESIS, Value: "("},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
 
-		{Type: lexer.FROM, Value: "FROM"},
-		{Type: lexer.IDENT, Value: "user"},
-		{Type: lexer.WHERE, Value: "WHERE"},
-		{Type: lexer.IDENT, Value: "name"},
-		{Type: lexer.IDENT, Value: "="},
-		{Type: lexer.STRING, Value: "'xxx'"},
-		{Type: lexer.EOF, Value: "EOF"},
-	}
-	testingData2 := []lexer.Token{
-		{Type: lexer.SELECT, Value: "SELECT"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.FROM, Value: "FROM"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.WHERE, Value: "WHERE"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.IN, Value: "IN"},
-		{Type: lexer.STARTPARENTHESIS, Value: "("},
-		{Type: lexer.SELECT, Value: "SELECT"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.FROM, Value: "FROM"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.JOIN, Value: "JOIN"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ON, Value: "ON"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.IDENT, Value: "="},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ENDPARENTHESIS, Value: ")"},
-		{Type: lexer.GROUP, Value: "GROUP"},
-		{Type: lexer.BY, Value: "BY"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.ORDER, Value: "ORDER"},
-		{Type: lexer.BY, Value: "BY"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.LIMIT, Value: "LIMIT"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.UNION, Value: "UNION"},
-		{Type: lexer.ALL, Value: "ALL"},
-		{Type: lexer.SELECT, Value: "SELECT"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.FROM, Value: "FROM"},
-		{Type: lexer.IDENT, Value: "xxx"},
-		{Type: lexer.EOF, Value: "EOF"},
-	}
-	testingData3 := []lexer.Token{
-		{Type: lexer.UPDATE, Value: "UPDATE"},
-		{Type: lexer.IDENT, Value: "user"},
-		{Type: lexer.SET, Value: "SET"},
-		{Type: lexer.IDENT, Value: "point"},
-		{Type: lexer.IDENT, Value: "="},
-		{Type: lexer.IDENT, Value: "0"},
-		{Type: lexer.EOF, Value: "EOF"},
-	}
+			{Type: lexer.TYPE, Value: "TEXT"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
 
-	tests := []struct {
-		name        string
-		tokenSource []lexer.Token
-		want        []group.Reindenter
-	}{
-		{
-			name:        "normal test case 1",
-			tokenSource: testingData,
-			want: []group.Reindenter{
-				&group.Select{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
-						lexer.Token{Type: lexer.IDENT, Value: "name"},
-						lexer.Token{Type: lexer.COMMA, Value: ","},
-						lexer.Token{Type: lexer.IDENT, Value: "age"},
-						lexer.Token{Type: lexer.COMMA, Value: ","},
-						&group.Function{
-							Element: []group.Reindenter{
-								lexer.Token{Type: lexer.FUNCTION, Value: "SUM"},
-								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
-								lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
-							},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.IDENT, Value: "user"},
+			{Type: lexer.WHERE, Value: "WHERE"},
+			{Type: lexer.IDENT, Value: "name"},
+			{Type: lexer.IDENT, Value: "="},
+			{Type: lexer.STRING, Value: "'xxx'"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []group.Reindenter{
+			&group.Select{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+					lexer.Token{Type: lexer.IDENT, Value: "name"},
+					lexer.Token{Type: lexer.COMMA, Value: ","},
+					lexer.Token{Type: lexer.IDENT, Value: "age"},
+					lexer.Token{Type: lexer.COMMA, Value: ","},
+					&group.Function{
+						Element: []group.Reindenter{
+							lexer.Token{Type: lexer.FUNCTION, Value: "SUM"},
+							lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+							lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+							lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
 						},
-						&group.Parenthesis{
-							Element: []group.Reindenter{
-								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
-								lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
-							},
+					},
+					&group.Parenthesis{
+						Element: []group.Reindenter{
+							lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+							lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+							lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
 						},
-						&group.TypeCast{
-							Element: []group.Reindenter{
-								lexer.Token{Type: lexer.TYPE, Value: "TEXT"},
-								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
-								lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
-							},
+					},
+					&group.TypeCast{
+						Element: []group.Reindenter{
+							lexer.Token{Type: lexer.TYPE, Value: "TEXT"},
+							lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+							lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+							lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
 						},
 					},
 				},
-				&group.From{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.FROM, Value: "FROM"},
-						lexer.Token{Type: lexer.IDENT, Value: "user"},
-					},
+			},
+			&group.From{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.FROM, Value: "FROM"},
+					lexer.Token{Type: lexer.IDENT, Value: "user"},
 				},
-				&group.Where{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.WHERE, Value: "WHERE"},
-						lexer.Token{Type: lexer.IDENT, Value: "name"},
-						lexer.Token{Type: lexer.IDENT, Value: "="},
-						lexer.Token{Type: lexer.STRING, Value: "'xxx'"},
-					},
+			},
+			&group.Where{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.WHERE, Value: "WHERE"},
+					lexer.Token{Type: lexer.IDENT, Value: "name"},
+					lexer.Token{Type: lexer.IDENT, Value: "="},
+					lexer.Token{Type: lexer.STRING, Value: "'xxx'"},
 				},
 			},
 		},
-		{
-			name:        "normal test case 2",
-			tokenSource: testingData2,
-			want: []group.Reindenter{
-				&group.Select{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+	},
+	{
+		name: "normal test case 2",
+		tokenSource: []lexer.Token{
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.WHERE, Value: "WHERE"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.IN, Value: "IN"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.JOIN, Value: "JOIN"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ON, Value: "ON"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.IDENT, Value: "="},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.GROUP, Value: "GROUP"},
+			{Type: lexer.BY, Value: "BY"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ORDER, Value: "ORDER"},
+			{Type: lexer.BY, Value: "BY"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.LIMIT, Value: "LIMIT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.UNION, Value: "UNION"},
+			{Type: lexer.ALL, Value: "ALL"},
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []group.Reindenter{
+			&group.Select{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.From{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.FROM, Value: "FROM"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.From{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.FROM, Value: "FROM"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.Where{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.WHERE, Value: "WHERE"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-						lexer.Token{Type: lexer.IN, Value: "IN"},
-						&group.Subquery{
-							Element: []group.Reindenter{
-								lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
-								&group.Select{
-									Element: []group.Reindenter{
-										lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
-										lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-									},
-									IndentLevel: 1,
+			},
+			&group.Where{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.WHERE, Value: "WHERE"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+					lexer.Token{Type: lexer.IN, Value: "IN"},
+					&group.Subquery{
+						Element: []group.Reindenter{
+							lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+							&group.Select{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 								},
-								&group.From{
-									Element: []group.Reindenter{
-										lexer.Token{Type: lexer.FROM, Value: "FROM"},
-										lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-									},
-									IndentLevel: 1,
+								IndentLevel: 1,
+							},
+							&group.From{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.FROM, Value: "FROM"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 								},
-								&group.Join{
-									Element: []group.Reindenter{
-										lexer.Token{Type: lexer.JOIN, Value: "JOIN"},
-										lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-										lexer.Token{Type: lexer.ON, Value: "ON"},
-										lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-										lexer.Token{Type: lexer.IDENT, Value: "="},
-										lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-									},
-									IndentLevel: 1,
+								IndentLevel: 1,
+							},
+							&group.Join{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.JOIN, Value: "JOIN"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+									lexer.Token{Type: lexer.ON, Value: "ON"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+									lexer.Token{Type: lexer.IDENT, Value: "="},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 								},
-								lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+								IndentLevel: 1,
 							},
-							IndentLevel: 1,
+							lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
 						},
+						IndentLevel: 1,
 					},
 				},
-				&group.GroupBy{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.GROUP, Value: "GROUP"},
-						lexer.Token{Type: lexer.BY, Value: "BY"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.GroupBy{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.GROUP, Value: "GROUP"},
+					lexer.Token{Type: lexer.BY, Value: "BY"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.OrderBy{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
-						lexer.Token{Type: lexer.BY, Value: "BY"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.OrderBy{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.ORDER, Value: "ORDER"},
+					lexer.Token{Type: lexer.BY, Value: "BY"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.LimitClause{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.LIMIT, Value: "LIMIT"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.LimitClause{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.LIMIT, Value: "LIMIT"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.TieClause{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.UNION, Value: "UNION"},
-						lexer.Token{Type: lexer.ALL, Value: "ALL"},
-					},
+			},
+			&group.TieClause{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.UNION, Value: "UNION"},
+					lexer.Token{Type: lexer.ALL, Value: "ALL"},
 				},
-				&group.Select{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.Select{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
-				&group.From{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.FROM, Value: "FROM"},
-						lexer.Token{Type: lexer.IDENT, Value: "xxx"},
-					},
+			},
+			&group.From{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.FROM, Value: "FROM"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
 			},
 		},
-		{
-			name:        "normal test case 3",
-			tokenSource: testingData3,
-			want: []group.Reindenter{
-				&group.Update{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.UPDATE, Value: "UPDATE"},
-						lexer.Token{Type: lexer.IDENT, Value: "user"},
-					},
+	},
+	{
+		name: "normal test case 3",
+		tokenSource: []lexer.Token{
+			{Type: lexer.UPDATE, Value: "UPDATE"},
+			{Type: lexer.IDENT, Value: "user"},
+			{Type: lexer.SET, Value: "SET"},
+			{Type: lexer.IDENT, Value: "point"},
+			{Type: lexer.IDENT, Value: "="},
+			{Type: lexer.IDENT, Value: "0"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []group.Reindenter{
+			&group.Update{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.UPDATE, Value: "UPDATE"},
+					lexer.Token{Type: lexer.IDENT, Value: "user"},
+				},
+			},
+			&group.Set{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.SET, Value: "SET"},
+					lexer.Token{Type: lexer.IDENT, Value: "point"},
+					lexer.Token{Type: lexer.IDENT, Value: "="},
+					lexer.Token{Type: lexer.IDENT, Value: "0"},
+				},
+			},
+		},
+	},
+	{
+		name: "CREATE VIEW with subquery",
+		tokenSource: []lexer.Token{
+			{Type: lexer.CREATE, Value: "CREATE"},
+			{Type: lexer.VIEW, Value: "VIEW"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.AS, Value: "AS"},
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.FROM, Value: "FROM"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.WHERE, Value: "WHERE"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.OPERATOR, Value: "="},
+			{Type: lexer.IDENT, Value: "1"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.AS, Value: "AS"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []group.Reindenter{
+			&group.CreateView{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.CREATE, Value: "CREATE"},
+					lexer.Token{Type: lexer.VIEW, Value: "VIEW"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+					lexer.Token{Type: lexer.AS, Value: "AS"},
 				},
-				&group.Set{
-					Element: []group.Reindenter{
-						lexer.Token{Type: lexer.SET, Value: "SET"},
-						lexer.Token{Type: lexer.IDENT, Value: "point"},
-						lexer.Token{Type: lexer.IDENT, Value: "="},
-						lexer.Token{Type: lexer.IDENT, Value: "0"},
+			},
+			&group.Select{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+				},
+			},
+			&group.From{
+				Element: []group.Reindenter{
+					lexer.Token{Type: lexer.FROM, Value: "FROM"},
+					&group.Subquery{
+						Element: []group.Reindenter{
+							lexer.Token{Type: lexer.STARTPARENTHESIS, Value: "("},
+							&group.Select{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.SELECT, Value: "SELECT"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+								},
+								IndentLevel: 1,
+							},
+							&group.From{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.FROM, Value: "FROM"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+								},
+								IndentLevel: 1,
+							},
+							&group.Where{
+								Element: []group.Reindenter{
+									lexer.Token{Type: lexer.WHERE, Value: "WHERE"},
+									lexer.Token{Type: lexer.IDENT, Value: "xxx"},
+									lexer.Token{Type: lexer.OPERATOR, Value: "="},
+									lexer.Token{Type: lexer.IDENT, Value: "1"},
+								},
+								IndentLevel: 1,
+							},
+							lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"},
+						},
+						IndentLevel: 1,
 					},
+					lexer.Token{Type: lexer.AS, Value: "AS"},
+					lexer.Token{Type: lexer.IDENT, Value: "xxx"},
 				},
 			},
 		},
+	},
+}
+
+func TestParseTokensUnbalanced(t *testing.T) {
+	for _, src := range unbalancedTestingData {
+		t.Run(src, func(t *testing.T) {
+			tokens, err := lexer.NewTokenizer(src).GetTokens()
+			if err != nil {
+				t.Fatalf("ERROR: %#v", err)
+			}
+			if _, err := ParseTokens(tokens); err == nil {
+				t.Errorf("unbalanced %#v should be error", src)
+			}
+		})
 	}
-	for _, tt := range tests {
-		got, err := ParseTokens(tt.tokenSource)
-		if err != nil {
-			t.Errorf("ERROR: %#v", err)
-		}
-		if !reflect.DeepEqual(got, tt.want) {
-			t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
-		}
+}
+
+var unbalancedTestingData = []string{
+	"select xxx from xxx where (xxx",
+	"select xxx from xxx where xxx)",
+	"select xxx from xxx where xxx in ([1)]",
+	"select xxx[1 from xxx",
+	"select xxx from xxx}",
+}
+
+func TestMarkConditionGroups(t *testing.T) {
+	for _, tt := range markConditionGroupsTestingData {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []lexer.TokenType
+			for _, tok := range markConditionGroups(tt.tokenSource) {
+				got = append(got, tok.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\nwant %#v, \ngot %#v", tt.want, got)
+			}
+		})
 	}
 }
+
+var markConditionGroupsTestingData = []struct {
+	name        string
+	tokenSource []lexer.Token
+	want        []lexer.TokenType
+}{
+	{
+		name: "AND, OR in WHERE and parenthesis",
+		tokenSource: []lexer.Token{
+			{Type: lexer.SELECT, Value: "SELECT"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.AND, Value: "AND"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.WHERE, Value: "WHERE"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.AND, Value: "AND"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.OR, Value: "OR"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []lexer.TokenType{
+			lexer.SELECT, lexer.IDENT, lexer.AND, lexer.IDENT,
+			lexer.WHERE, lexer.IDENT, lexer.ANDGROUP,
+			lexer.STARTPARENTHESIS, lexer.IDENT, lexer.ORGROUP, lexer.IDENT, lexer.ENDPARENTHESIS,
+			lexer.EOF,
+		},
+	},
+	{
+		name: "BETWEEN and function in HAVING",
+		tokenSource: []lexer.Token{
+			{Type: lexer.HAVING, Value: "HAVING"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.BETWEEN, Value: "BETWEEN"},
+			{Type: lexer.IDENT, Value: "1"},
+			{Type: lexer.AND, Value: "AND"},
+			{Type: lexer.IDENT, Value: "2"},
+			{Type: lexer.OR, Value: "OR"},
+			{Type: lexer.FUNCTION, Value: "xxx"},
+			{Type: lexer.STARTPARENTHESIS, Value: "("},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.AND, Value: "AND"},
+			{Type: lexer.IDENT, Value: "xxx"},
+			{Type: lexer.ENDPARENTHESIS, Value: ")"},
+			{Type: lexer.EOF, Value: "EOF"},
+		},
+		want: []lexer.TokenType{
+			lexer.HAVING, lexer.IDENT, lexer.BETWEEN, lexer.IDENT, lexer.AND, lexer.IDENT,
+			lexer.ORGROUP, lexer.FUNCTION,
+			lexer.STARTPARENTHESIS, lexer.IDENT, lexer.AND, lexer.IDENT, lexer.ENDPARENTHESIS,
+			lexer.EOF,
+		},
+	},
+}
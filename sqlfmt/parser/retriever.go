@@ -15,6 +15,8 @@ type Retriever struct {
 	indentLevel   int
 	endTokenTypes []lexer.TokenType
 	endIdx        int
+	// depth is the depth of parenthesis appended to result as tokens, such as xxx IN (xxx) in function
+	depth int
 }
 
 // NewRetriever Creates Retriever that retrieves each target SQL clause
@@ -40,6 +42,8 @@ func NewRetriever(tokenSource []lexer.Token) *Retriever {
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfGroupBy}
 	case lexer.HAVING:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfHaving}
+	case lexer.WINDOW:
+		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfWindow}
 	case lexer.ORDER:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfOrderBy}
 	case lexer.LIMIT, lexer.FETCH, lexer.OFFSET:
@@ -62,12 +66,20 @@ func NewRetriever(tokenSource []lexer.Token) *Retriever {
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfValues}
 	case lexer.FUNCTION:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfFunction}
+	case lexer.OVER:
+		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfOver}
 	case lexer.TYPE:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfTypeCast}
 	case lexer.LOCK:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfLock}
 	case lexer.WITH:
 		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfWith}
+	case lexer.CREATE:
+		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfCreate}
+	case lexer.ALTER:
+		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfAlter}
+	case lexer.DROP:
+		return &Retriever{TokenSource: tokenSource, endTokenTypes: lexer.EndOfDrop}
 	default:
 		return nil
 	}
@@ -91,7 +103,7 @@ func (r *Retriever) appendGroupsToResult() error {
 		token lexer.Token
 	)
 	for {
-		if idx > len(r.TokenSource) {
+		if idx >= len(r.TokenSource) {
 			return fmt.Errorf("the retriever may have not found the endToken")
 		}
 
@@ -105,13 +117,21 @@ func (r *Retriever) appendGroupsToResult() error {
 			if !containsEndToken(subGroupRetriever.TokenSource, subGroupRetriever.endTokenTypes) {
 				return fmt.Errorf("sub group %s has no end key word", subGroupRetriever.TokenSource[0].Value)
 			}
-			subGroupRetriever.appendGroupsToResult()
+			if err := subGroupRetriever.appendGroupsToResult(); err != nil {
+				return err
+			}
 			if err := r.appendSubGroupToResult(subGroupRetriever.result, subGroupRetriever.indentLevel); err != nil {
 				return err
 			}
 			idx = subGroupRetriever.getNextTokenIdx(token.Type, idx)
 			continue
 		}
+		switch token.Type {
+		case lexer.STARTPARENTHESIS:
+			r.depth++
+		case lexer.ENDPARENTHESIS:
+			r.depth--
+		}
 		r.result = append(r.result, token)
 		idx++
 	}
@@ -132,6 +152,10 @@ func containsEndToken(tokenSource []lexer.Token, endTokenTypes []lexer.TokenType
 
 // isEndGroup determines if token is the end token
 func (r *Retriever) isEndGroup(token lexer.Token, endTokenTypes []lexer.TokenType, idx int) bool {
+	// ")" closing parenthesis in the group is not the end token
+	if token.Type == lexer.ENDPARENTHESIS && r.depth > 1 {
+		return false
+	}
 	for _, endTokenType := range r.endTokenTypes {
 		// ignore endTokens when first token type is equal to endTokenType because first token type might be a endTokenType. For example "AND","OR"
 		// isRangeOfJoinStart ignores if endTokenType appears in start of Join clause such as LEFT OUTER JOIN, INNER JOIN etc ...
@@ -152,11 +176,17 @@ func (r *Retriever) getSubGroupRetriever(idx int) *Retriever {
 	}
 
 	token := r.TokenSource[idx]
-	nextToken := r.TokenSource[idx+1]
+	nextToken := r.tokenAt(idx + 1)
 
 	if r.containIrregularGroupMaker(token.Type, idx) {
 		return nil
 	}
+	// if conditions such as (xxx OR xxx) are found, indentLevel of all tokens until ")" will be incremented
+	if token.Type == lexer.STARTPARENTHESIS && hasConditionGroup(r.TokenSource[idx:]) {
+		subR := NewRetriever(r.TokenSource[idx:])
+		subR.indentLevel = r.indentLevel + 1
+		return subR
+	}
 	if token.Type == lexer.STARTPARENTHESIS && nextToken.Type == lexer.SELECT {
 		subR := NewRetriever(r.TokenSource[idx:])
 		subR.indentLevel = r.indentLevel
@@ -208,13 +238,52 @@ func (r *Retriever) containIrregularGroupMaker(ttype lexer.TokenType, idx int) b
 		return true
 	}
 
-	if ttype == lexer.TYPE && !(r.TokenSource[idx+1].Type == lexer.STARTPARENTHESIS) {
+	if ttype == lexer.TYPE && !(r.tokenAt(idx+1).Type == lexer.STARTPARENTHESIS) {
+		return true
+	}
+
+	// in order to ignore OVER with window name such as OVER xxx
+	if ttype == lexer.OVER && !(r.tokenAt(idx+1).Type == lexer.STARTPARENTHESIS) {
+		return true
+	}
+
+	// in order to ignore "(" of window specification and ORDER BY in it
+	if firstTokenOfCurrentGroup.Type == lexer.OVER && ((ttype == lexer.STARTPARENTHESIS && idx == 1) || ttype == lexer.ORDER) {
 		return true
 	}
 
 	return false
 }
 
+// tokenAt returns the token at idx, or EOF token if idx is out of TokenSource
+func (r *Retriever) tokenAt(idx int) lexer.Token {
+	if idx >= len(r.TokenSource) {
+		return lexer.Token{Type: lexer.EOF, Value: "EOF"}
+	}
+	return r.TokenSource[idx]
+}
+
+// hasConditionGroup determines if ANDGROUP or ORGROUP is in the top level of parenthesis starting from tokenSource[0]
+func hasConditionGroup(tokenSource []lexer.Token) bool {
+	var depth int
+	for _, tok := range tokenSource {
+		switch tok.Type {
+		case lexer.STARTPARENTHESIS:
+			depth++
+		case lexer.ENDPARENTHESIS:
+			depth--
+		case lexer.ANDGROUP, lexer.ORGROUP:
+			if depth == 1 {
+				return true
+			}
+		}
+		if depth == 0 {
+			return false
+		}
+	}
+	return false
+}
+
 // if group key words to make join group such as "LEFT" or "OUTER" appear within idx is in range of join group, any keyword must be ignored not be made into a sub group
 func (r *Retriever) isRangeOfJoinStart(idx int) bool {
 	firstTokenType := r.TokenSource[0].Type
@@ -229,6 +298,9 @@ func (r *Retriever) isRangeOfJoinStart(idx int) bool {
 
 // appendSubGroupToResult makes Reindenter from subGroup result and append it to result
 func (r *Retriever) appendSubGroupToResult(result []group.Reindenter, lev int) error {
+	if len(result) == 0 {
+		return fmt.Errorf("sub group is empty")
+	}
 	if subGroup := createGroup(result); subGroup != nil {
 		subGroup.IncrementIndentLevel(lev)
 		r.result = append(r.result, subGroup)
@@ -244,7 +316,7 @@ func (r *Retriever) getNextTokenIdx(ttype lexer.TokenType, idx int) int {
 	// In this case, next token must start after those end keyword, so it adds 1 to idx
 
 	switch ttype {
-	case lexer.STARTPARENTHESIS, lexer.CASE, lexer.FUNCTION, lexer.TYPE:
+	case lexer.STARTPARENTHESIS, lexer.CASE, lexer.FUNCTION, lexer.OVER, lexer.TYPE:
 		idx += r.endIdx + 1
 	default:
 		idx += r.endIdx
@@ -275,6 +347,8 @@ func createGroup(tokenSource []group.Reindenter) group.Reindenter {
 		return &group.OrderBy{Element: tokenSource}
 	case lexer.HAVING:
 		return &group.Having{Element: tokenSource}
+	case lexer.WINDOW:
+		return &group.Window{Element: tokenSource}
 	case lexer.LIMIT, lexer.OFFSET, lexer.FETCH:
 		return &group.LimitClause{Element: tokenSource}
 	case lexer.UNION, lexer.INTERSECT, lexer.EXCEPT:
@@ -295,6 +369,12 @@ func createGroup(tokenSource []group.Reindenter) group.Reindenter {
 		return &group.Delete{Element: tokenSource}
 	case lexer.WITH:
 		return &group.With{Element: tokenSource}
+	case lexer.CREATE:
+		return createDDLGroup(tokenSource)
+	case lexer.ALTER:
+		return &group.AlterTable{Element: tokenSource}
+	case lexer.DROP:
+		return &group.Drop{Element: tokenSource}
 	// endKeyWord of CASE group("END") has to be included in the group, so it is appended to result
 	case lexer.CASE:
 		endToken := lexer.Token{Type: lexer.END, Value: "END"}
@@ -315,6 +395,11 @@ func createGroup(tokenSource []group.Reindenter) group.Reindenter {
 		tokenSource = append(tokenSource, endToken)
 
 		return &group.Function{Element: tokenSource}
+	case lexer.OVER:
+		endToken := lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"}
+		tokenSource = append(tokenSource, endToken)
+
+		return &group.Over{Element: tokenSource}
 	case lexer.TYPE:
 		endToken := lexer.Token{Type: lexer.ENDPARENTHESIS, Value: ")"}
 		tokenSource = append(tokenSource, endToken)
@@ -323,3 +408,21 @@ func createGroup(tokenSource []group.Reindenter) group.Reindenter {
 	}
 	return nil
 }
+
+// createDDLGroup creates CREATE statement group depending on the object to be created
+func createDDLGroup(tokenSource []group.Reindenter) group.Reindenter {
+	for _, v := range tokenSource {
+		if token, ok := v.(lexer.Token); ok {
+			switch token.Type {
+			case lexer.INDEX:
+				return &group.CreateIndex{Element: tokenSource}
+			case lexer.VIEW:
+				return &group.CreateView{Element: tokenSource}
+			case lexer.TABLE:
+				return &group.CreateTable{Element: tokenSource}
+			}
+		}
+	}
+	// the other objects such as CREATE SEQUENCE are formatted in the same way as CREATE TABLE
+	return &group.CreateTable{Element: tokenSource}
+}
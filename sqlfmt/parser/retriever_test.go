@@ -108,6 +108,25 @@ func TestRetrieve(t *testing.T) {
 				lastIdx: 3,
 			},
 		},
+		{
+			name: "parenthesis in function",
+			source: []lexer.Token{
+				{Type: lexer.FUNCTION, Value: "COUNT"},
+				{Type: lexer.STARTPARENTHESIS, Value: "("},
+				{Type: lexer.IDENT, Value: "xxx"},
+				{Type: lexer.IN, Value: "IN"},
+				{Type: lexer.STARTPARENTHESIS, Value: "("},
+				{Type: lexer.IDENT, Value: "1"},
+				{Type: lexer.ENDPARENTHESIS, Value: ")"},
+				{Type: lexer.ENDPARENTHESIS, Value: ")"},
+				{Type: lexer.FROM, Value: "FROM"},
+			},
+			endTokenTypes: lexer.EndOfFunction,
+			want: &want{
+				stmt:    []string{"COUNT", "(", "xxx", "IN", "(", "1", ")"},
+				lastIdx: 7,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
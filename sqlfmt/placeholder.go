@@ -0,0 +1,23 @@
+package sqlfmt
+
+import (
+	"github.com/kanmu/go-sqlfmt/sqlfmt/lexer"
+	"github.com/pkg/errors"
+)
+
+// Placeholders returns bind parameters such as $1, ?, :name and @name in src in order of appearance
+func Placeholders(src string) ([]string, error) {
+	t := lexer.NewTokenizer(src)
+	tokens, err := t.GetTokens()
+	if err != nil {
+		return nil, errors.Wrap(err, "Tokenize failed")
+	}
+
+	var result []string
+	for _, tok := range tokens {
+		if tok.Type == lexer.PLACEHOLDER {
+			result = append(result, tok.Value)
+		}
+	}
+	return result, nil
+}
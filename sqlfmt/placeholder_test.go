@@ -0,0 +1,46 @@
+package sqlfmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "PostgreSQL style",
+			src:  `select xxx from xxx where xxx = $1::int and xxx in ($2, $3)`,
+			want: []string{"$1", "$2", "$3"},
+		},
+		{
+			name: "MySQL style",
+			src:  `select xxx from xxx where xxx = ? and xxx = ?`,
+			want: []string{"?", "?"},
+		},
+		{
+			name: "named style",
+			src:  `update xxx set xxx = :xxx where xxx = @id`,
+			want: []string{":xxx", "@id"},
+		},
+		{
+			name: "no placeholder",
+			src:  `select xxx::int, @1 from xxx`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Placeholders(tt.src)
+			if err != nil {
+				t.Errorf("should be nil, got %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("want %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package sqlfmt
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Query is a SQL statement passed to Query, QueryRow and Exec functions in .go file
+type Query struct {
+	// Func is the name of the function such as Query
+	Func string
+	// SQL is the value of the string literal
+	SQL string
+	// Start and End are the byte range of the string literal in .go file
+	Start int
+	End   int
+	// Raw is true if SQL is in a raw string literal, so that the byte offset n in SQL is Start+1+n in .go file
+	Raw bool
+	// PrintfVerbs is true if SQL is the template of fmt.Sprintf
+	PrintfVerbs bool
+	// Args is the number of arguments passed after SQL statement
+	Args int
+	// Variadic is true if the last argument is a slice passed with ... such as args...
+	Variadic bool
+}
+
+// Queries returns SQL statements in string literals passed to Query, QueryRow and Exec functions in .go file
+// concatenation of string literals such as "SELECT xxx " + "FROM xxx" is regarded as a SQL statement
+func Queries(filename string, src []byte) ([]Query, error) {
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, formatErr(errors.Wrap(err, "parser.ParseFile failed"))
+	}
+
+	var queries []Query
+	inspectCalls(astFile, func(call *ast.CallExpr) {
+		q := Query{
+			Func:     call.Fun.(*ast.SelectorExpr).Sel.Name,
+			Args:     len(call.Args) - 1,
+			Variadic: call.Ellipsis.IsValid(),
+		}
+
+		arg := call.Args[0]
+		if sprintf, ok := arg.(*ast.CallExpr); ok && isSprintf(sprintf) && len(sprintf.Args) > 0 {
+			arg, q.PrintfVerbs = sprintf.Args[0], true
+		}
+		sql, ok := constantString(arg)
+		if !ok {
+			return
+		}
+		q.SQL = sql
+		q.Start, q.End = fset.Position(arg.Pos()).Offset, fset.Position(arg.End()).Offset
+		if lit, ok := arg.(*ast.BasicLit); ok && strings.HasPrefix(lit.Value, "`") && !strings.Contains(lit.Value, "\r") {
+			q.Raw = true
+		}
+		queries = append(queries, q)
+	})
+	return queries, nil
+}
+
+// constantString returns the value of string literal or concatenation of string literals
+func constantString(expr ast.Expr) (string, bool) {
+	var result string
+	for _, operand := range flattenConcat(expr) {
+		lit, ok := operand.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", false
+		}
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", false
+		}
+		result += v
+	}
+	return result, true
+}
@@ -0,0 +1,36 @@
+package sqlfmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueries(t *testing.T) {
+	src := "package main\n\nfunc main() {\n" +
+		"\tdb.Query(`select xxx from xxx`, 1)\n" +
+		"\tdb.QueryRow(\"select xxx \" + \"from xxx\")\n" +
+		"\tdb.Exec(fmt.Sprintf(`delete from %s`, table))\n" +
+		"\tdb.Exec(query)\n" +
+		"\tdb.Exec(`select xxx`, args...)\n" +
+		"\tu.Query()\n" +
+		"}\n"
+
+	want := []Query{
+		{Func: "Query", SQL: "select xxx from xxx", Start: 38, End: 59, Raw: true, Args: 1},
+		{Func: "QueryRow", SQL: "select xxx from xxx", Start: 77, End: 103},
+		{Func: "Exec", SQL: "delete from %s", Start: 126, End: 142, Raw: true, PrintfVerbs: true},
+		{Func: "Exec", SQL: "select xxx", Start: 177, End: 189, Raw: true, Args: 1, Variadic: true},
+	}
+	got, err := Queries("main.go", []byte(src))
+	if err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("\nwant %#v, \ngot %#v", want, got)
+	}
+	for _, q := range got {
+		if q.Raw && src[q.Start+1:q.End-1] != q.SQL {
+			t.Errorf("offset of %#v does not point to SQL", q)
+		}
+	}
+}
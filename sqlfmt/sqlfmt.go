@@ -1,43 +1,111 @@
 package sqlfmt
 
 import (
-	"bytes"
-	"go/format"
+	"fmt"
 	"go/parser"
-	"go/printer"
 	"go/token"
+	"log"
+	"sort"
 
 	"github.com/pkg/errors"
 )
 
+// Style is the layout of formatted SQL statements
+type Style int
+
+// styles of formatted SQL statements
+const (
+	// Pretty writes clauses on separate lines with indentation
+	Pretty Style = iota
+	// Compact writes SQL statement on one line with single spaces
+	Compact
+)
+
 // Options for go-sqlfmt
 type Options struct {
 	Distance int
+	// Style is the layout of formatted SQL statements, which is Pretty by default
+	// Distance and AutoIndent are ignored if Style is Compact
+	Style Style
+	// Verify re-formats the formatted statement and returns an error if it changes
+	Verify bool
+	// FoldConcat folds concatenated string literals such as "SELECT xxx " + "FROM xxx" into a raw string literal to format it
+	FoldConcat bool
+	// ConvertStrings formats interpreted string literals such as "SELECT xxx" and converts them into raw string literals
+	ConvertStrings bool
+	// AutoIndent indents SQL statements and closing back quotes one level deeper than the Go code calling Query, QueryRow and Exec
+	// Distance is ignored if AutoIndent is true
+	AutoIndent bool
+	// PrintfVerbs formats templates of fmt.Sprintf, in which printf verbs such as %s, %d are treated as placeholders
+	// it is set for the templates passed to Query, QueryRow and Exec functions in Process
+	PrintfVerbs bool
+	// ErrorHandler is called with the error of each string literal which failed to be formatted in Process
+	// the error is logged if ErrorHandler is nil
+	ErrorHandler func(err *LiteralError)
+}
+
+func (o *Options) handleError(err *LiteralError) {
+	if o.ErrorHandler != nil {
+		o.ErrorHandler(err)
+		return
+	}
+	log.Println(err)
 }
 
 // Process formats SQL statement in .go file
 func Process(filename string, src []byte, options *Options) ([]byte, error) {
-	fset := token.NewFileSet()
-	parserMode := parser.ParseComments
+	return ProcessRange(filename, src, 0, len(src), options)
+}
 
-	astFile, err := parser.ParseFile(fset, filename, src, parserMode)
+// ProcessRange formats SQL statements in .go file which overlap the byte range between start and end
+// the other SQL statements are left untouched
+func ProcessRange(filename string, src []byte, start, end int, options *Options) ([]byte, error) {
+	return ProcessRanges(filename, src, []Range{{Start: start, End: end}}, options)
+}
+
+// ProcessRanges formats SQL statements in .go file which overlap any of ranges
+// the other SQL statements are left untouched
+func ProcessRanges(filename string, src []byte, ranges []Range, options *Options) ([]byte, error) {
+	edits, err := EditsRanges(filename, src, ranges, options)
 	if err != nil {
-		return nil, formatErr(errors.Wrap(err, "parser.ParseFile failed"))
+		return nil, err
 	}
+	// only byte ranges of SQL statements are rewritten so that the other part of src is kept as it is
+	return ApplyEdits(src, edits), nil
+}
 
-	replaceAst(astFile, fset, options)
+// Edits returns edits replacing SQL statements in .go file with formatted ones
+// edits are sorted by Start and do not overlap
+func Edits(filename string, src []byte, options *Options) ([]Edit, error) {
+	return EditsRange(filename, src, 0, len(src), options)
+}
 
-	var buf bytes.Buffer
+// EditsRange returns edits replacing SQL statements in .go file which overlap the byte range between start and end
+// edits are sorted by Start and do not overlap
+func EditsRange(filename string, src []byte, start, end int, options *Options) ([]Edit, error) {
+	return EditsRanges(filename, src, []Range{{Start: start, End: end}}, options)
+}
 
-	if err = printer.Fprint(&buf, fset, astFile); err != nil {
-		return nil, formatErr(errors.Wrap(err, "printer.Fprint failed"))
+// EditsRanges returns edits replacing SQL statements in .go file which overlap any of ranges
+// edits are sorted by Start and do not overlap
+func EditsRanges(filename string, src []byte, ranges []Range, options *Options) ([]Edit, error) {
+	for _, r := range ranges {
+		if r.Start < 0 || r.End > len(src) || r.Start > r.End {
+			return nil, formatErr(fmt.Errorf("invalid range %d:%d of %s", r.Start, r.End, filename))
+		}
 	}
 
-	out, err := format.Source(buf.Bytes())
+	fset := token.NewFileSet()
+	parserMode := parser.ParseComments
+
+	astFile, err := parser.ParseFile(fset, filename, src, parserMode)
 	if err != nil {
-		return nil, formatErr(errors.Wrap(err, "format.Source failed"))
+		return nil, formatErr(errors.Wrap(err, "parser.ParseFile failed"))
 	}
-	return out, nil
+
+	edits := collectEdits(astFile, fset, src, ranges, options)
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Start < edits[j].Start })
+	return edits, nil
 }
 
 func formatErr(err error) error {
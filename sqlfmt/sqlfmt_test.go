@@ -0,0 +1,95 @@
+package sqlfmt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProcessRange(t *testing.T) {
+	src := "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Exec(`select yyy from yyy`)\n}\n"
+	first := "package main\n\nfunc main() {\n\tdb.Query(`\nSELECT\n  xxx\nFROM xxx`)\n\tdb.Exec(`select yyy from yyy`)\n}\n"
+	second := "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Exec(`\nSELECT\n  yyy\nFROM yyy`)\n}\n"
+	both := "package main\n\nfunc main() {\n\tdb.Query(`\nSELECT\n  xxx\nFROM xxx`)\n\tdb.Exec(`\nSELECT\n  yyy\nFROM yyy`)\n}\n"
+
+	firstLit := strings.Index(src, "`select xxx")
+	secondLit := strings.Index(src, "`select yyy")
+	tests := []struct {
+		name  string
+		start int
+		end   int
+		want  string
+	}{
+		{
+			name:  "cursor in the first literal",
+			start: firstLit + 5,
+			end:   firstLit + 5,
+			want:  first,
+		},
+		{
+			name:  "cursor just after the second literal",
+			start: secondLit + len("`select yyy from yyy`"),
+			end:   secondLit + len("`select yyy from yyy`"),
+			want:  second,
+		},
+		{
+			name:  "range over both literals",
+			start: firstLit,
+			end:   secondLit + 1,
+			want:  both,
+		},
+		{
+			name:  "range without literals",
+			start: 0,
+			end:   len("package main"),
+			want:  src,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProcessRange("main.go", []byte(src), tt.start, tt.end, &Options{})
+			if err != nil {
+				t.Fatalf("should be nil, got %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("want %#v, got %#v", tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestProcessRangeInvalid(t *testing.T) {
+	src := []byte("package main\n")
+	for _, r := range [][2]int{{-1, 0}, {0, len(src) + 1}, {5, 4}} {
+		if _, err := ProcessRange("main.go", src, r[0], r[1], &Options{}); err == nil {
+			t.Errorf("range %d:%d should be invalid", r[0], r[1])
+		}
+	}
+}
+
+func TestProcessRanges(t *testing.T) {
+	src := "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Exec(`select yyy from yyy`)\n\tdb.Exec(`select zzz from zzz`)\n}\n"
+	want := "package main\n\nfunc main() {\n\tdb.Query(`\nSELECT\n  xxx\nFROM xxx`)\n\tdb.Exec(`select yyy from yyy`)\n\tdb.Exec(`\nSELECT\n  zzz\nFROM zzz`)\n}\n"
+
+	first, third := strings.Index(src, "xxx"), strings.Index(src, "zzz")
+	got, err := ProcessRanges("main.go", []byte(src), []Range{{Start: first, End: first}, {Start: third, End: third}}, &Options{})
+	if err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("want %#v, got %#v", want, string(got))
+	}
+}
+
+func TestEditsRange(t *testing.T) {
+	src := "package main\n\nfunc main() {\n\tdb.Query(`select xxx from xxx`)\n\tdb.Exec(`select yyy from yyy`)\n}\n"
+	second := strings.Index(src, "yyy")
+	got, err := EditsRange("main.go", []byte(src), second, second, &Options{})
+	if err != nil {
+		t.Fatalf("should be nil, got %v", err)
+	}
+	want := []Edit{{Start: 70, End: 91, Text: "`\nSELECT\n  yyy\nFROM yyy`"}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,4 @@
+
+ALTER TABLE xxx
+  ADD CONSTRAINT xxx FOREIGN KEY (xxx_id) REFERENCES xxx (id) ON DELETE CASCADE
+  , ADD CONSTRAINT xxx CHECK (a > 0 AND b > 0)
@@ -0,0 +1 @@
+alter table xxx add constraint xxx foreign key (xxx_id) references xxx (id) on delete cascade, add constraint xxx check (a > 0 and b > 0)
@@ -0,0 +1,29 @@
+//sqlfmt -indent=auto -fold-concat
+
+package testdata
+
+import (
+	"database/sql"
+)
+
+func autoIndent(db *sql.DB, ids []int) error {
+	for _, id := range ids {
+		if id > 0 {
+			_, err := db.Exec(`update xxx set xxx = 1 where id = $1`, id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// indenting the string literal spanning lines would change its value
+	if _, err := db.Exec(`insert into xxx (xxx) values ('line1
+line2')`); err != nil {
+		return err
+	}
+	rows, err := db.Query("select xxx from xxx " +
+		"where xxx = $1")
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,29 @@
+//sqlfmt -compact -fold-concat -indent=auto
+
+package testdata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func compact(db *sql.DB, table, where string) {
+	db.Query(`
+SELECT
+  xxx
+  , count(*)
+  , xxx::int
+FROM xxx
+JOIN xxx
+ON xxx = xxx
+WHERE xxx IN ($1, $2)
+AND xxx = ANY(array[1, 2])
+GROUP BY
+  xxx
+ORDER BY
+  xxx DESC
+LIMIT 10`, 1, 2)
+	db.Exec(`update xxx set xxx = 'a  b' where xxx in (select xxx from xxx where xxx = $1)`, 1)
+	db.Query(fmt.Sprintf(`select xxx   from %s`, table))
+	db.Query(`select xxx from xxx ` + where + " order by   xxx")
+}
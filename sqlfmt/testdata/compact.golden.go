@@ -0,0 +1,15 @@
+//sqlfmt -compact -fold-concat -indent=auto
+
+package testdata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func compact(db *sql.DB, table, where string) {
+	db.Query(`SELECT xxx, COUNT(*), xxx::int FROM xxx JOIN xxx ON xxx = xxx WHERE xxx IN ($1, $2) AND xxx = ANY(array[1, 2]) GROUP BY xxx ORDER BY xxx DESC LIMIT 10`, 1, 2)
+	db.Exec(`UPDATE xxx SET xxx = 'a  b' WHERE xxx IN (SELECT xxx FROM xxx WHERE xxx = $1)`, 1)
+	db.Query(fmt.Sprintf(`SELECT xxx FROM %s`, table))
+	db.Query(`SELECT xxx FROM xxx ` + where + " order by   xxx")
+}
@@ -0,0 +1,23 @@
+//sqlfmt -fold-concat
+
+package testdata
+
+import (
+	"database/sql"
+)
+
+func constant(db *sql.DB) {
+	db.Query("select xxx "+
+		"from xxx "+
+		"where xxx = $1", 1)
+}
+
+func dynamic(db *sql.DB, where string) {
+	db.Query(`select xxx from xxx `+where+" order by xxx", 1)
+	db.Query("select xxx from xxx where xxx in (" + "$1" + ")")
+}
+
+func notSQL(db *sql.DB, table string) {
+	db.Query("select xxx from " + table)
+	db.Query("xxx" + "xxx")
+}
@@ -0,0 +1,33 @@
+//sqlfmt -fold-concat
+
+package testdata
+
+import (
+	"database/sql"
+)
+
+func constant(db *sql.DB) {
+	db.Query(`
+SELECT
+  xxx
+FROM xxx
+WHERE xxx = $1`, 1)
+}
+
+func dynamic(db *sql.DB, where string) {
+	db.Query(`
+SELECT
+  xxx
+FROM xxx
+`+where+" order by xxx", 1)
+	db.Query(`
+SELECT
+  xxx
+FROM xxx
+WHERE xxx IN ($1)`)
+}
+
+func notSQL(db *sql.DB, table string) {
+	db.Query("select xxx from " + table)
+	db.Query("xxx" + "xxx")
+}
@@ -0,0 +1,19 @@
+
+SELECT
+  xxx
+  , COUNT(xxx)
+FROM xxx
+LEFT JOIN xxx
+ON xxx.id = xxx.id
+AND xxx.deleted_at IS NULL
+WHERE xxx.created_at BETWEEN $1 AND $2
+AND (
+  xxx.status = 'active'
+  OR xxx.status = 'pending'
+)
+GROUP BY
+  xxx
+HAVING COUNT(xxx) > 1
+ORDER BY
+  xxx DESC
+LIMIT 10
@@ -0,0 +1,6 @@
+select xxx, count(xxx) from xxx
+left join xxx on xxx.id = xxx.id and xxx.deleted_at is null
+where xxx.created_at between $1 and $2 and (xxx.status = 'active' or xxx.status = 'pending')
+group by xxx having count(xxx) > 1
+order by xxx desc
+limit 10
@@ -0,0 +1,19 @@
+//sqlfmt -convert-strings
+
+package testdata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func convertStrings(db *sql.DB, table string) {
+	db.Query("select xxx from xxx where xxx = $1 and xxx = 'a\tb'", 1)
+	db.Query(fmt.Sprintf("select xxx from %s\n", table))
+	// back quotes can not be in raw string literal
+	db.Query("select `xxx` from xxx")
+	db.Query("select xxx from xxx\r\n")
+	// NUL and invalid UTF-8 can not be in Go source
+	db.Query("select xxx from xxx where xxx = '\x00'")
+	db.Query("select xxx from xxx where xxx = '\xff'")
+}
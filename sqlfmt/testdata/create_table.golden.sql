@@ -0,0 +1,6 @@
+
+CREATE TABLE IF NOT EXISTS xxx (
+  id BIGSERIAL PRIMARY KEY
+  , name TEXT NOT NULL
+  , created_at TIMESTAMP DEFAULT now()
+)
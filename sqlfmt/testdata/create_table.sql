@@ -0,0 +1 @@
+create table if not exists xxx (id bigserial primary key, name text not null, created_at timestamp default now())
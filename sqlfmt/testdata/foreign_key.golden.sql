@@ -0,0 +1,6 @@
+
+CREATE TABLE xxx (
+  id BIGSERIAL PRIMARY KEY
+  , xxx_id bigint REFERENCES xxx (id) ON DELETE CASCADE
+  , CHECK (a > 0 AND b > 0)
+)
@@ -0,0 +1 @@
+create table xxx (id bigserial primary key, xxx_id bigint references xxx (id) on delete cascade, check (a > 0 and b > 0))
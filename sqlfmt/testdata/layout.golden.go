@@ -0,0 +1,13 @@
+package testdata
+
+import "database/sql"
+
+// layout of Go code which is not formatted by gofmt is kept as it is
+func layout(db *sql.DB)  {
+    rows, err := db.Query(`
+SELECT
+  xxx
+FROM xxx`,   1) // comment after query
+    if err != nil { return }
+	defer rows.Close()
+}
@@ -0,0 +1,10 @@
+package testdata
+
+import "database/sql"
+
+// file without SQL statements to be formatted is kept byte-identical
+func noSQL(db *sql.DB)  {
+    db.Query("select xxx from xxx" )   // interpreted string literal
+	var   x = 1
+	_ = x
+}
@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"database/sql"
+)
+
+// sendSQL formats the query of QueryRow
+func sendSQL(db *sql.DB) (int, error) {
+	var id int
+	err := db.QueryRow(`select id from xxx where name = $1 and deleted_at is null`, "xxx").Scan(&id)
+	return id, err
+}
+
+// notSQL leaves the string as it is
+func notSQL(db *sql.DB) {
+	db.Query("select id from xxx")
+}
@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"database/sql"
+)
+
+// sendSQL formats the query of QueryRow
+func sendSQL(db *sql.DB) (int, error) {
+	var id int
+	err := db.QueryRow(`
+SELECT
+  id
+FROM xxx
+WHERE name = $1
+AND deleted_at IS NULL`, "xxx").Scan(&id)
+	return id, err
+}
+
+// notSQL leaves the string as it is
+func notSQL(db *sql.DB) {
+	db.Query("select id from xxx")
+}
@@ -0,0 +1,12 @@
+package testdata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func sprintf(db *sql.DB, table string, limit int) {
+	db.Query(fmt.Sprintf(`select xxx, xxx %% 2 from %s join xxx_%[1]s on xxx.id = %[1]s.id where xxx = $1 and xxx like 'x%%' limit %d`, table, limit), 1)
+	db.Exec(fmt.Sprintf(`update %s set xxx = %v where xxx %% 2 = 0`, table, 1))
+	db.Query(`select xxx % 2 from xxx where xxx like 'x%'`)
+}
@@ -0,0 +1,30 @@
+package testdata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func sprintf(db *sql.DB, table string, limit int) {
+	db.Query(fmt.Sprintf(`
+SELECT
+  xxx
+  , xxx %% 2
+FROM %s
+JOIN xxx_%[1]s
+ON xxx.id = %[1]s.id
+WHERE xxx = $1
+AND xxx LIKE 'x%%'
+LIMIT %d`, table, limit), 1)
+	db.Exec(fmt.Sprintf(`
+UPDATE
+  %s
+SET
+  xxx = %v
+WHERE xxx %% 2 = 0`, table, 1))
+	db.Query(`
+SELECT
+  xxx % 2
+FROM xxx
+WHERE xxx LIKE 'x%'`)
+}
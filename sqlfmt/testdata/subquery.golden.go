@@ -0,0 +1,21 @@
+package sqlfmt
+
+import (
+	"database/sql"
+)
+
+func sendSQL() int {
+	var id int
+	var db *sql.DB
+	db.QueryRow(`
+SELECT
+  any (
+    SELECT
+      xxx
+    FROM xxx
+  )
+FROM xxx
+WHERE xxx
+LIMIT xxx`).Scan(&id)
+	return id
+}
@@ -0,0 +1,10 @@
+
+SELECT
+  xxx
+  , rank() OVER (
+    PARTITION BY xxx
+    ORDER BY xxx DESC
+  )
+FROM xxx
+WINDOW
+  w AS (PARTITION BY xxx)
@@ -0,0 +1 @@
+select xxx, rank() over (partition by xxx order by xxx desc) from xxx window w as (partition by xxx)
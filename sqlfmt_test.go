@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLineOffsets(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		from, to  int
+		wantStart int
+		wantEnd   int
+	}{
+		{name: "first line", src: "line1\nline2\nline3\n", from: 1, to: 1, wantStart: 0, wantEnd: 5},
+		{name: "multiple lines", src: "line1\nline2\nline3\n", from: 2, to: 3, wantStart: 6, wantEnd: 17},
+		{name: "empty line after the last new line", src: "line1\nline2\nline3\n", from: 4, to: 4, wantStart: 18, wantEnd: 18},
+		{name: "lines after the end", src: "line1\nline2\nline3\n", from: 10, to: 12, wantStart: 18, wantEnd: 18},
+		{name: "last line without new line", src: "line1\nline2", from: 2, to: 5, wantStart: 6, wantEnd: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := lineOffsets([]byte(tt.src), tt.from, tt.to)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("want %d:%d, got %d:%d", tt.wantStart, tt.wantEnd, start, end)
+			}
+		})
+	}
+}